@@ -0,0 +1,121 @@
+package profanityout
+
+import (
+	"strings"
+)
+
+const (
+	bbcodeMaxTagLen = 256
+)
+
+var (
+	// bbcodeInlineTags are formatting tags inside text, they are skipped so "[b]fu[/b]ck" is scanned as "fuck"
+	bbcodeInlineTags = map[string]struct{}{
+		"b": {}, "i": {}, "u": {}, "s": {}, "color": {}, "size": {}, "font": {}, "url": {}, "email": {},
+		"sub": {}, "sup": {}, "highlight": {},
+	}
+	// bbcodeBlockTags are tags separating words, they are scanned as spaces
+	bbcodeBlockTags = map[string]struct{}{
+		"quote": {}, "code": {}, "list": {}, "*": {}, "center": {}, "left": {}, "right": {}, "justify": {},
+		"indent": {}, "spoiler": {}, "hr": {}, "br": {}, "table": {}, "tr": {}, "td": {}, "th": {},
+		"img": {}, "youtube": {}, "video": {}, "media": {},
+	}
+	// bbcodeExcludedContentTags are tags whose content is not text (code, image URLs, video IDs)
+	bbcodeExcludedContentTags = map[string]struct{}{
+		"code": {}, "img": {}, "youtube": {}, "video": {}, "media": {},
+	}
+)
+
+type bbcodeTag struct {
+	name    string // lowercase name
+	closing bool
+	start   int
+	end     int
+}
+
+// parseBBCodeTagAt parses a tag such as "[b]", "[/b]", "[url=https://example.com]", "[quote name=x]"
+// at the position, ok is false when there is no known tag
+func parseBBCodeTagAt(input []rune, i int) (tag bbcodeTag, ok bool) {
+	if input[i] != '[' {
+		return tag, false
+	}
+	j := i + 1
+	for ; j < len(input) && j-i < bbcodeMaxTagLen; j++ {
+		if ch := input[j]; ch == ']' {
+			break
+		} else if ch == '[' || ch == '\n' {
+			return tag, false
+		}
+	}
+	if j >= len(input) || input[j] != ']' {
+		return tag, false
+	}
+	content := string(input[i+1 : j])
+	if strings.HasPrefix(content, "/") {
+		tag.closing = true
+		content = content[1:]
+	}
+	name := content
+	if k := strings.IndexAny(content, "= "); k >= 0 {
+		if tag.closing {
+			return tag, false
+		}
+		name = content[:k]
+	}
+	tag.name = strings.ToLower(name)
+	if _, exists := bbcodeInlineTags[tag.name]; !exists {
+		if _, exists = bbcodeBlockTags[tag.name]; !exists {
+			return tag, false
+		}
+	}
+	tag.start, tag.end = i, j+1
+	return tag, true
+}
+
+// parseBBCode classifies the characters of BBCode text. Inline tags are hidden, block tags are scanned as
+// spaces. Content of code, image and video tags is not scanned, neither is quoted content when
+// excludeQuotes is true.
+func parseBBCode(input []rune, excludeQuotes bool) []markupClass {
+	markup := make([]markupClass, len(input))
+	excludedTag := "" // the tag whose content is being excluded
+	quoteDepth := 0
+	for i := 0; i < len(input); {
+		tag, ok := parseBBCodeTagAt(input, i)
+		if !ok {
+			if excludedTag != "" || quoteDepth > 0 {
+				markup[i] = markupSpace
+			}
+			i++
+			continue
+		}
+
+		if excludedTag != "" {
+			if tag.closing && tag.name == excludedTag {
+				excludedTag = ""
+			} else {
+				markMarkup(markup, tag.start, tag.end, markupSpace)
+				i = tag.end
+				continue
+			}
+		} else if _, exists := bbcodeExcludedContentTags[tag.name]; exists && !tag.closing {
+			excludedTag = tag.name
+		}
+		if tag.name == "quote" && excludeQuotes {
+			if tag.closing {
+				if quoteDepth > 0 {
+					quoteDepth--
+				}
+			} else {
+				quoteDepth++
+			}
+		}
+
+		class := markupSpace
+		if _, exists := bbcodeInlineTags[tag.name]; exists {
+			class = markupHidden
+		}
+		markMarkup(markup, tag.start, tag.end, class)
+		i = tag.end
+	}
+	return markup
+}
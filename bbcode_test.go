@@ -0,0 +1,56 @@
+package profanityout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseBBCode(t *testing.T) {
+	classes := func(text string, excludeQuotes bool) string {
+		return markupClasses(parseBBCode([]rune(text), excludeQuotes))
+	}
+
+	assert.Equal(t, "HHHTTHHHHTT", classes("[b]fu[/b]ck", false))
+	assert.Equal(t, "HHHHHHHHHTHHHHHH", classes("[url=x.y]a[/url]", false))
+	assert.Equal(t, "TT____TT", classes("fu[br]ck", false))
+	assert.Equal(t, "TTTTTT", classes("[fu]ck", false))
+	assert.Equal(t, "______________", classes("[code]a[/code]", false))
+	assert.Equal(t, "_______T________", classes("[quote]a[/quote]", false))
+	assert.Equal(t, "________________", classes("[quote]a[/quote]", true))
+	assert.Equal(t, strings.Repeat("_", 31)+"T", classes("[quote][quote][/quote]b[/quote]c", true))
+}
+
+func Test_Scan_BBCode(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithProcessInputAsBBCode(true)
+	}
+	var m Matches
+
+	m = d().ScanAllProfanities("x [b]fu[/b]ck y")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.Equal(t, 5, m[0].Start)
+	assert.Equal(t, 13, m[0].End)
+
+	// Tag attributes, code and image URLs are not scanned
+	m = d().ScanAllProfanities("[url=https://fuck.com]shit[/url] [img]x/fuck.png[/img] [code]cunt[/code]")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, []rune("shit"), m[0].Text)
+
+	m = d().ScanAllProfanities("[quote=bob]fuck you[/quote] no")
+	assert.True(t, m.HasProfaneMatch())
+	m = d().ScanAllProfanities("[quote=bob]fuck you[/quote] no", WithBBCodeExcludeQuotes(true))
+	assert.False(t, m.HasProfaneMatch())
+
+	m = d().ScanAllProfanities("x [b]fu[/b]ck", WithProcessInputAsBBCode(false))
+	assert.False(t, m.HasProfaneMatch())
+}
+
+func Test_Censor_BBCode(t *testing.T) {
+	d := newDetectorEN().WithProcessInputAsBBCode(true).WithCensorCharacter('#')
+
+	s, _ := d.Censor("x [b]fu[/b]ck, [url=https://example.com]shit[/url] [code]fuck[/code]")
+	assert.Equal(t, "x [b]##[/b]##, [url=https://example.com]####[/url] [code]fuck[/code]", s)
+}
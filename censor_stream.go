@@ -0,0 +1,105 @@
+package profanityout
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrWriterClosed = errors.New("profanityout: writer is closed")
+)
+
+// CensorWriter censors profanities in the data written to it and writes the result to the underlying writer.
+// Data is held back only as long as needed to decide on a possible match. Call Close to flush the remaining data.
+type CensorWriter struct {
+	w      io.Writer
+	stream *streamScanner
+	buf    []byte
+	err    error
+	closed bool
+}
+
+// NewCensorWriter creates a CensorWriter which applies the same censoring as ProfanityDetector.Censor
+func NewCensorWriter(w io.Writer, detector *ProfanityDetector, options ...DetectorOption) *CensorWriter {
+	cw := &CensorWriter{w: w}
+	cw.stream = detector.newStreamScanner(nil, options...)
+	cw.stream.output = cw.writeOutput
+	return cw
+}
+
+func (cw *CensorWriter) writeOutput(text string) {
+	if cw.err != nil {
+		return
+	}
+	cw.buf = append(cw.buf[:0], text...)
+	_, cw.err = cw.w.Write(cw.buf)
+}
+
+// Write implements io.Writer
+func (cw *CensorWriter) Write(p []byte) (int, error) {
+	if cw.closed {
+		return 0, ErrWriterClosed
+	}
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	cw.stream.write(p)
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	return len(p), nil
+}
+
+// Close flushes all pending data to the underlying writer. The underlying writer is not closed.
+func (cw *CensorWriter) Close() error {
+	if cw.closed {
+		return nil
+	}
+	cw.closed = true
+	if cw.err != nil {
+		return cw.err
+	}
+	cw.stream.close()
+	return cw.err
+}
+
+// CensorReader reads data from the underlying reader and censors all profanities in it.
+type CensorReader struct {
+	r      io.Reader
+	stream *streamScanner
+	chunk  []byte
+	out    []byte // censored data ready to be read
+	err    error
+}
+
+// NewCensorReader creates a CensorReader which applies the same censoring as ProfanityDetector.Censor
+func NewCensorReader(r io.Reader, detector *ProfanityDetector, options ...DetectorOption) *CensorReader {
+	cr := &CensorReader{r: r, chunk: make([]byte, streamReadSize)}
+	cr.stream = detector.newStreamScanner(nil, options...)
+	cr.stream.output = func(text string) {
+		cr.out = append(cr.out, text...)
+	}
+	return cr
+}
+
+// Read implements io.Reader
+func (cr *CensorReader) Read(p []byte) (int, error) {
+	for len(cr.out) == 0 {
+		if cr.err != nil {
+			return 0, cr.err
+		}
+		n, err := cr.r.Read(cr.chunk)
+		if n > 0 {
+			cr.stream.write(cr.chunk[:n])
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				cr.stream.close()
+			}
+			cr.err = err
+		}
+	}
+	n := copy(p, cr.out)
+	cr.out = cr.out[n:]
+	return n, nil
+}
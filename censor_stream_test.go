@@ -0,0 +1,142 @@
+package profanityout
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CensorWriter(t *testing.T) {
+	d := newDetectorEN()
+
+	t.Run("Same result as Censor", func(t *testing.T) {
+		text := strings.Repeat("hello wörld, fuck this $h!!t and f u c k 😀 analytics bada$$ a $ $ x ", 100)
+		expected, _ := d.Censor(text)
+
+		var out bytes.Buffer
+		w := NewCensorWriter(&out, d, WithStreamLookback(30))
+		for i := 0; i < len(text); i += 7 {
+			end := i + 7
+			if end > len(text) {
+				end = len(text)
+			}
+			n, err := w.Write([]byte(text[i:end]))
+			assert.Nil(t, err)
+			assert.Equal(t, end-i, n)
+		}
+		assert.Nil(t, w.Close())
+		assert.Equal(t, expected, out.String())
+	})
+
+	t.Run("Hold back data until decided", func(t *testing.T) {
+		var out bytes.Buffer
+		w := NewCensorWriter(&out, d, WithCensorCharacter('#'))
+		_, _ = w.Write([]byte("so fu"))
+		assert.Equal(t, "", out.String())
+		_, _ = w.Write([]byte("ck"))
+		assert.Nil(t, w.Close())
+		assert.Equal(t, "so ####", out.String())
+
+		_, err := w.Write([]byte("x"))
+		assert.ErrorIs(t, err, ErrWriterClosed)
+	})
+
+	t.Run("One byte at a time", func(t *testing.T) {
+		for _, text := range []string{
+			strings.Repeat("x ", 2080) + "fúck",
+			strings.Repeat("café fúck \xff x ", 300),
+		} {
+			expected, _ := d.Censor(text)
+
+			var out bytes.Buffer
+			w := NewCensorWriter(&out, d)
+			for i := 0; i < len(text); i++ {
+				_, _ = w.Write([]byte{text[i]})
+			}
+			assert.Nil(t, w.Close())
+			assert.Equal(t, expected, out.String())
+		}
+
+		var out bytes.Buffer
+		w := NewCensorWriter(&out, d)
+		text := strings.Repeat("x ", 2080) + "fúck"
+		for i := 0; i < len(text); i++ {
+			_, _ = w.Write([]byte{text[i]})
+		}
+		assert.Nil(t, w.Close())
+		assert.Equal(t, 4164, out.Len())
+		assert.True(t, strings.HasSuffix(out.String(), " ****"))
+	})
+
+	t.Run("Flush decided data on each write", func(t *testing.T) {
+		var out bytes.Buffer
+		w := NewCensorWriter(&out, d, WithStreamLookback(30))
+		_, _ = w.Write([]byte(strings.Repeat("fuck x ", 10)))
+		assert.True(t, strings.HasPrefix(out.String(), "**** x **** x "))
+		assert.Less(t, out.Len(), 70)
+		assert.Nil(t, w.Close())
+		assert.Equal(t, strings.Repeat("**** x ", 10), out.String())
+	})
+
+	t.Run("Invalid UTF-8 bytes are kept", func(t *testing.T) {
+		text := "a \xff fuck \xfe b"
+		expected, _ := d.Censor(text)
+		assert.Equal(t, "a \xff **** \xfe b", expected)
+
+		var out bytes.Buffer
+		w := NewCensorWriter(&out, d)
+		_, _ = w.Write([]byte(text))
+		assert.Nil(t, w.Close())
+		assert.Equal(t, expected, out.String())
+	})
+
+	t.Run("HTML entities", func(t *testing.T) {
+		text := strings.Repeat("<p>x &lt;ock f<b>uc</b>k &amp; y</p>", 50)
+		expected, _ := d.Censor(text, WithProcessInputAsHTML(true))
+
+		var out bytes.Buffer
+		w := NewCensorWriter(&out, d, WithProcessInputAsHTML(true), WithStreamLookback(30))
+		for i := 0; i < len(text); i += 5 {
+			_, _ = w.Write([]byte(text[i:minInt(i+5, len(text))]))
+		}
+		assert.Nil(t, w.Close())
+		assert.Equal(t, expected, out.String())
+	})
+
+	t.Run("Underlying writer error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		w := NewCensorWriter(errorWriter{err: errTest}, d)
+		_, err := w.Write([]byte(strings.Repeat("text ", 1000)))
+		assert.ErrorIs(t, err, errTest)
+		assert.ErrorIs(t, w.Close(), errTest)
+	})
+}
+
+func Test_CensorReader(t *testing.T) {
+	d := newDetectorEN()
+
+	text := strings.Repeat("hello wörld, fuck this $h!!t and f u c k 😀 analytics bada$$ a $ $ x ", 100)
+	expected, _ := d.Censor(text)
+
+	r := NewCensorReader(iotest.HalfReader(strings.NewReader(text)), d)
+	res, err := io.ReadAll(iotest.OneByteReader(r))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(res))
+
+	errTest := errors.New("test error")
+	_, err = io.ReadAll(NewCensorReader(iotest.ErrReader(errTest), d))
+	assert.ErrorIs(t, err, errTest)
+}
+
+type errorWriter struct {
+	err error
+}
+
+func (w errorWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
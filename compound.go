@@ -0,0 +1,178 @@
+package profanityout
+
+import (
+	"unicode"
+)
+
+const (
+	// Longer runs of letters are not split into dictionary words
+	compoundMaxRunLen = 32
+)
+
+// splitCompoundWords returns positions of word edges inside compound words such as hashtags,
+// camelCase, snake_case, words with trailing numbers and concatenated words ("fuckthis").
+// Concatenated words are only split when the whole run of letters consists of dictionary words.
+func (s *scanner) splitCompoundWords() (edges []int) {
+	input := s.input
+	for i := 0; i < len(input); {
+		if s.isTokenSeparator(input[i]) && !s.isHashtagAt(i) {
+			i++
+			continue
+		}
+		start := i
+		for i++; i < len(input) && !s.isTokenSeparator(input[i]); i++ {
+		}
+		edges = s.splitToken(start, i, edges)
+	}
+	return edges
+}
+
+// isTokenSeparator checks if the character separates tokens. Leet speak characters are
+// parts of tokens when leet speak sanitization is on.
+func (s *scanner) isTokenSeparator(ch rune) bool {
+	if unicode.IsLetter(ch) || unicode.IsDigit(ch) {
+		return false
+	}
+	if s.settings.SanitizeLeetSpeak && s.leetSpeakCharacters[ch] != 0 {
+		return false
+	}
+	return true
+}
+
+// isHashtagAt checks if a hashtag or a mention starts at the position: "#fuckthis", "@fuckthis"
+func (s *scanner) isHashtagAt(i int) bool {
+	input := s.input
+	return (input[i] == '#' || input[i] == '@') && i+1 < len(input) && !s.isTokenSeparator(input[i+1])
+}
+
+// splitToken finds the word edges in the token input[start:end]
+func (s *scanner) splitToken(start, end int, edges []int) []int {
+	input := s.input
+
+	// Hashtags and mentions: "#fuckthis", "@fuckthis"
+	if (input[start] == '#' || input[start] == '@') && end-start > 1 {
+		start++
+		edges = append(edges, start)
+	}
+
+	// Trailing numbers: "fuck123"
+	digitStart := end
+	for digitStart > start && unicode.IsDigit(input[digitStart-1]) {
+		digitStart--
+	}
+	if digitStart > start && digitStart < end {
+		edges = append(edges, digitStart)
+		end = digitStart
+	}
+
+	// The whole token consists of dictionary words: "FUCKthis"
+	if split, found := s.splitConcatenatedWords(start, end, edges); found {
+		return split
+	}
+
+	// camelCase and PascalCase: "BigAssDog", "HTMLParser"
+	partStart := start
+	for i := start + 1; i <= end; i++ {
+		if i < end && !isCamelCaseEdge(input, start, end, i) {
+			continue
+		}
+		if i < end {
+			edges = append(edges, i)
+		}
+		edges, _ = s.splitConcatenatedWords(partStart, i, edges)
+		partStart = i
+	}
+	return edges
+}
+
+func isCamelCaseEdge(input []rune, start, end, i int) bool {
+	prev, curr := input[i-1], input[i]
+	if unicode.IsLower(prev) && unicode.IsUpper(curr) {
+		return true
+	}
+	return i > start && i+1 < end && unicode.IsUpper(prev) && unicode.IsUpper(curr) && unicode.IsLower(input[i+1])
+}
+
+// splitConcatenatedWords splits the part input[start:end] into the fewest dictionary words
+// covering the whole part, nothing is split and false is returned when such words are not found
+func (s *scanner) splitConcatenatedWords(start, end int, edges []int) ([]int, bool) {
+	length := end - start
+	if length < 2 || length > compoundMaxRunLen { //nolint:mnd
+		return edges, false
+	}
+	letters := make([]rune, length)
+	for i := range letters {
+		ch := unicode.ToLower(s.input[start+i])
+		if lsCh, exists := s.leetSpeakCharacters[ch]; exists && s.settings.SanitizeLeetSpeak {
+			ch = lsCh
+		}
+		if !unicode.IsLetter(ch) {
+			return edges, false
+		}
+		letters[i] = ch
+	}
+
+	// count[i] is the fewest words covering letters[i:], next[i] is the end of the first of them
+	count := make([]int, length+1)
+	next := make([]int, length+1)
+	for i := length - 1; i >= 0; i-- {
+		for j := length; j > i; j-- { // longer words first
+			if count[j] < 0 || (count[i] > 0 && count[j]+1 >= count[i]) {
+				continue
+			}
+			if s.isDictionaryWord(string(letters[i:j])) {
+				count[i], next[i] = count[j]+1, j
+			}
+		}
+		if count[i] == 0 {
+			count[i] = -1
+		}
+	}
+	if count[0] < 0 || s.isSplitFalsePositive(letters, next) {
+		return edges, false
+	}
+	for i := next[0]; i < length; i = next[i] {
+		edges = append(edges, start+i)
+	}
+	return edges, true
+}
+
+// isSplitFalsePositive checks if a false positive word is split by the edges: "cumin" is not "cum" + "in"
+func (s *scanner) isSplitFalsePositive(letters []rune, next []int) bool {
+	isEdge := make([]bool, len(letters))
+	for i := next[0]; i < len(letters); i = next[i] {
+		isEdge[i] = true
+	}
+	for i := range letters {
+		current := s.falsePositiveTree.root
+		for j := i; j < len(letters); j++ {
+			if current = current.Next(letters[j]); current == nil {
+				break
+			}
+			if current.word == nil {
+				continue
+			}
+			for k := i + 1; k <= j; k++ {
+				if isEdge[k] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s *scanner) isDictionaryWord(word string) bool {
+	if _, exists := s.commonWords[word]; exists {
+		return true
+	}
+	for _, wordTree := range []*tree{s.falsePositiveTree, s.profanityTree, s.skeletonTree} {
+		if wordTree == nil {
+			continue
+		}
+		if node := wordTree.root.NextPath(word); node != nil && node.word != nil {
+			return true
+		}
+	}
+	return false
+}
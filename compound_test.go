@@ -0,0 +1,79 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_Scan_SplitCompoundWords(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithCommonWords(en.DefaultCommonWords).WithSplitCompoundWords(true)
+	}
+	var m Matches
+
+	m = d().ScanAllProfanities("x BigAssDog")
+	assert.Equal(t, &Match{Word: "ass", Start: 5, End: 8, WordType: WordTypeProfanity,
+		Text: []rune("Ass"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("x #fuckthis")
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.Equal(t, 7, m[0].End)
+
+	m = d().ScanAllProfanities("x shitpost, FUCKthis, fuck123")
+	assert.Equal(t, 3, len(m))
+	assert.Equal(t, []rune("shit"), m[0].Text)
+	assert.Equal(t, []rune("FUCK"), m[1].Text)
+	assert.Equal(t, []rune("fuck"), m[2].Text)
+
+	// Leet speak is sanitized when splitting
+	m = d().ScanAllProfanities("x sh1tpost")
+	assert.Equal(t, []rune("sh1t"), m[0].Text)
+
+	// Positions refer to the original text
+	m = d().ScanAllProfanities("héllo BigAssDog")
+	assert.Equal(t, 9, m[0].Start)
+	assert.Equal(t, 10, m[0].ByteStart)
+
+	// Concatenated words are not split when the parts are not all dictionary words
+	m = d().ScanAllProfanities("x classname, passion, assassin, shitxyz")
+	assert.False(t, m.HasProfaneMatch())
+
+	// Common words and false positives are not split
+	m = d().ScanAllProfanities("x cumin, #cumin")
+	assert.Nil(t, m)
+	m = newDetectorEN().WithCommonWords([]string{"big", "in"}).WithSplitCompoundWords(true).
+		ScanAllProfanities("x bigcumin")
+	assert.True(t, m.HasProfaneMatch())
+	m = newDetectorEN().WithCommonWords([]string{"big", "in"}).WithFalsePositiveWords([]string{"gcumi"}).
+		WithSplitCompoundWords(true).ScanAllProfanities("x bigcumin")
+	assert.False(t, m.HasProfaneMatch())
+
+	m = d().ScanAllProfanities("x BigAssDog", WithSplitCompoundWords(false))
+	assert.Nil(t, m)
+}
+
+func Test_SplitCompoundWords(t *testing.T) {
+	s := newDetectorEN().WithCommonWords(en.DefaultCommonWords).newScanner(true)
+	split := func(text string) []int {
+		s.input = []rune(text)
+		return s.splitCompoundWords()
+	}
+
+	assert.Equal(t, []int{3, 6}, split("BigAssDog"))
+	assert.Equal(t, []int{4}, split("HTMLParser"))
+	assert.Equal(t, []int{1, 5}, split("#fuckthis"))
+	assert.Equal(t, []int{4}, split("fuck123"))
+	assert.Equal(t, []int{4}, split("shitpost"))
+	assert.Equal(t, []int{5}, split("classname"))
+	assert.Equal(t, []int{12}, split("big_dog shitpost"))
+	assert.Nil(t, split("passion"))
+	assert.Nil(t, split("cumin"))
+	assert.Equal(t, []int{3, 7}, split("x @fuckthis"))
+
+	// Hashtags are split when '#' is not a leet speak character
+	s = newDetectorEN().WithCommonWords(en.DefaultCommonWords).WithSanitizeLeetSpeak(false).newScanner(true)
+	assert.Equal(t, []int{1, 5}, split("#fuckthis"))
+}
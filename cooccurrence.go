@@ -0,0 +1,261 @@
+package profanityout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultCooccurrenceWindow = 5
+
+	// cooccurrenceCategoryPrefix marks a category term of a rule ("@you")
+	cooccurrenceCategoryPrefix = "@"
+	// cooccurrenceSuspectTerm matches any suspect match
+	cooccurrenceSuspectTerm = "@suspect"
+)
+
+var (
+	ErrInvalidCooccurrenceRule = errors.New("profanityout: invalid co-occurrence rule")
+)
+
+// CooccurrenceRule combines words found near each other into a new match.
+// Terms are words or categories ("@you"), "@suspect" matches any suspect match.
+// All terms must be found within Window consecutive words (default: 5).
+type CooccurrenceRule struct {
+	Label    string   `json:"label"`
+	Terms    []string `json:"terms"`
+	Window   int      `json:"window,omitempty"`
+	WordType WordType `json:"wordType,omitempty"` // "suspect" or "profanity" (default: WordTypeProfanity)
+}
+
+// CooccurrenceRuleSet is a set of co-occurrence rules with the categories of words they use
+type CooccurrenceRuleSet struct {
+	Categories map[string][]string `json:"categories"`
+	Rules      []CooccurrenceRule  `json:"rules"`
+}
+
+// ParseCooccurrenceRules parses a rule set from JSON data, such as:
+//
+//	{
+//	  "categories": {"you": ["you", "u", "ur", "yourself"]},
+//	  "rules": [{"label": "threat", "terms": ["kill", "@you"], "window": 2, "wordType": "suspect"}]
+//	}
+func ParseCooccurrenceRules(data []byte) (*CooccurrenceRuleSet, error) {
+	ruleSet := &CooccurrenceRuleSet{}
+	if err := json.Unmarshal(data, ruleSet); err != nil {
+		return nil, err
+	}
+	if err := ruleSet.validate(); err != nil {
+		return nil, err
+	}
+	return ruleSet, nil
+}
+
+func (rs *CooccurrenceRuleSet) validate() error {
+	for _, rule := range rs.Rules {
+		if rule.Label == "" || len(rule.Terms) < 2 || rule.Window < 0 { //nolint:mnd
+			return fmt.Errorf("%w: %q", ErrInvalidCooccurrenceRule, rule.Label)
+		}
+		if rule.WordType != 0 && rule.WordType != WordTypeSuspect && rule.WordType != WordTypeProfanity {
+			return fmt.Errorf("%w: %q: invalid word type %d", ErrInvalidCooccurrenceRule, rule.Label, rule.WordType)
+		}
+		for _, term := range rule.Terms {
+			category, isCategory := strings.CutPrefix(term, cooccurrenceCategoryPrefix)
+			if _, exists := rs.Categories[category]; isCategory && !exists && term != cooccurrenceSuspectTerm {
+				return fmt.Errorf("%w: %q: unknown category %q", ErrInvalidCooccurrenceRule, rule.Label, term)
+			}
+		}
+	}
+	return nil
+}
+
+// cooccurrenceRules is the compiled form of a rule set
+type cooccurrenceRules struct {
+	rules      []CooccurrenceRule
+	categories map[string]map[string]struct{} // words of the categories keyed by "@category"
+}
+
+func newCooccurrenceRules(ruleSet *CooccurrenceRuleSet) *cooccurrenceRules {
+	compiled := &cooccurrenceRules{categories: map[string]map[string]struct{}{}}
+	for category, words := range ruleSet.Categories {
+		set := make(map[string]struct{}, len(words))
+		for _, word := range words {
+			set[removeAccents(strings.ToLower(normalizeAsNFC(word)))] = struct{}{}
+		}
+		compiled.categories[cooccurrenceCategoryPrefix+category] = set
+	}
+	for _, rule := range ruleSet.Rules {
+		terms := make([]string, 0, len(rule.Terms))
+		for _, term := range rule.Terms {
+			if !strings.HasPrefix(term, cooccurrenceCategoryPrefix) {
+				term = removeAccents(strings.ToLower(normalizeAsNFC(term)))
+			}
+			terms = append(terms, term)
+		}
+		rule.Terms = terms
+		if rule.Window == 0 {
+			rule.Window = defaultCooccurrenceWindow
+		}
+		if rule.WordType == 0 {
+			rule.WordType = WordTypeProfanity
+		}
+		compiled.rules = append(compiled.rules, rule)
+	}
+	return compiled
+}
+
+// matchesWord checks if the term matches the word of a token or a match
+func (rs *cooccurrenceRules) matchesWord(term, word string) bool {
+	if words, exists := rs.categories[term]; exists {
+		_, exists = words[word]
+		return exists
+	}
+	return term == word
+}
+
+// cooccurrenceItem is a word or a match found at a token of the input
+type cooccurrenceItem struct {
+	index      int // index of the token
+	start, end int
+	words      []string // the words of the token, or the word of the match
+	match      *Match
+}
+
+func (rs *cooccurrenceRules) matchesItem(term string, item *cooccurrenceItem) bool {
+	if item.match != nil && term == cooccurrenceSuspectTerm {
+		return item.match.IsSuspect()
+	}
+	for _, word := range item.words {
+		if rs.matchesWord(term, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyCooccurrenceRules combines the words and the matches found near each other into new matches
+// according to the rules. Suspect matches combined are replaced by the new matches.
+func (s *scanner) applyCooccurrenceRules(matches Matches) Matches {
+	if !s.hasCooccurrenceRules() {
+		return matches
+	}
+	tokens := s.readPhraseTokens()
+	items := make([]*cooccurrenceItem, 0, len(tokens)+len(matches))
+	for i, token := range tokens {
+		items = append(items, &cooccurrenceItem{index: i, start: token.start, end: token.lettersEnd,
+			words: []string{token.sanitized, token.letters}})
+	}
+	for _, match := range matches {
+		if match.IsFalsePositive() {
+			continue
+		}
+		index := sort.Search(len(tokens), func(i int) bool { return tokens[i].end > match.Start })
+		items = append(items, &cooccurrenceItem{index: index, start: match.Start, end: match.End,
+			words: []string{match.Word}, match: match})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].index < items[j].index })
+
+	var found Matches
+	for i := range s.cooccurrenceRules.rules {
+		found = s.findCooccurrences(&s.cooccurrenceRules.rules[i], items, found)
+	}
+	if len(found) == 0 {
+		return matches
+	}
+	for _, match := range found {
+		kept := matches[:0]
+		for _, m := range matches {
+			if !m.IsSuspect() || m.Start < match.Start || m.End > match.End {
+				kept = append(kept, m)
+			}
+		}
+		matches = append(kept, match)
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	for _, match := range matches {
+		if match.Cooccurrence {
+			s.updateMatchOffsets(match)
+		}
+	}
+	return matches
+}
+
+func (s *scanner) hasCooccurrenceRules() bool {
+	return s.cooccurrenceRules != nil && len(s.cooccurrenceRules.rules) > 0
+}
+
+// findCooccurrences finds the windows of words where all terms of the rule are found, a window is skipped
+// when it overlaps a match found before, so earlier rules take precedence
+func (s *scanner) findCooccurrences(rule *CooccurrenceRule, items []*cooccurrenceItem, found Matches) Matches {
+	for first := range items {
+		window := items[first:]
+		for end := range window {
+			if window[end].index-items[first].index >= rule.Window {
+				window = window[:end]
+				break
+			}
+		}
+		used := make([]*cooccurrenceItem, len(rule.Terms))
+		if !s.assignCooccurrenceTerms(rule.Terms, window, used) || !containsItem(used, items[first]) {
+			continue
+		}
+		match := &Match{Word: rule.Label, WordType: rule.WordType, Start: used[0].start, End: used[0].end,
+			HeadSpace: true, TailSpace: true, Settings: s.settings, Cooccurrence: true}
+		for _, item := range used[1:] {
+			match.Start, match.End = minInt(match.Start, item.start), maxInt(match.End, item.end)
+		}
+		if isOverlapped(match, found) {
+			continue
+		}
+		match.Text = s.inputOrig[match.Start:match.End]
+		if !s.settings.ConfidenceCalculator(match) {
+			continue
+		}
+		found = append(found, match)
+	}
+	return found
+}
+
+// assignCooccurrenceTerms assigns the terms to items at different tokens
+func (s *scanner) assignCooccurrenceTerms(terms []string, items []*cooccurrenceItem,
+	used []*cooccurrenceItem) bool {
+	termIndex := 0
+	for termIndex < len(used) && used[termIndex] != nil {
+		termIndex++
+	}
+	if termIndex == len(terms) {
+		return true
+	}
+	for _, item := range items {
+		if !s.cooccurrenceRules.matchesItem(terms[termIndex], item) || isTokenUsed(used, item.index) {
+			continue
+		}
+		used[termIndex] = item
+		if s.assignCooccurrenceTerms(terms, items, used) {
+			return true
+		}
+		used[termIndex] = nil
+	}
+	return false
+}
+
+func isTokenUsed(used []*cooccurrenceItem, index int) bool {
+	for _, item := range used {
+		if item != nil && item.index == index {
+			return true
+		}
+	}
+	return false
+}
+
+func containsItem(items []*cooccurrenceItem, target *cooccurrenceItem) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package profanityout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_ParseCooccurrenceRules(t *testing.T) {
+	ruleSet, err := ParseCooccurrenceRules([]byte(en.DefaultCooccurrenceRules))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(ruleSet.Rules))
+	assert.Equal(t, "self-harm", ruleSet.Rules[0].Label)
+	assert.Equal(t, WordTypeSuspect, ruleSet.Rules[1].WordType)
+
+	// Word types can be names or numbers
+	ruleSet, err = ParseCooccurrenceRules([]byte(`{"rules": [{"label": "x", "terms": ["a", "b"], "wordType": 10},
+		{"label": "y", "terms": ["a", "b"], "wordType": "profanity"}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, WordTypeSuspect, ruleSet.Rules[0].WordType)
+	assert.Equal(t, WordTypeProfanity, ruleSet.Rules[1].WordType)
+	_, err = ParseCooccurrenceRules([]byte(`{"rules": [{"label": "x", "terms": ["a", "b"], "wordType": "bad"}]}`))
+	assert.True(t, errors.Is(err, ErrInvalidWordType))
+	_, err = ParseCooccurrenceRules([]byte(`{"rules": [{"label": "x", "terms": ["a", "b"], "wordType": 30}]}`))
+	assert.True(t, errors.Is(err, ErrInvalidCooccurrenceRule))
+
+	_, err = ParseCooccurrenceRules([]byte(`{"rules": [{"label": "x", "terms": ["kill", "@unknown"]}]}`))
+	assert.True(t, errors.Is(err, ErrInvalidCooccurrenceRule))
+	_, err = ParseCooccurrenceRules([]byte(`{"rules": [{"label": "x", "terms": ["kill"]}]}`))
+	assert.True(t, errors.Is(err, ErrInvalidCooccurrenceRule))
+	_, err = ParseCooccurrenceRules([]byte(`{"rules": [`))
+	assert.NotNil(t, err)
+}
+
+func Test_Scan_Cooccurrence(t *testing.T) {
+	ruleSet, err := ParseCooccurrenceRules([]byte(en.DefaultCooccurrenceRules))
+	assert.Nil(t, err)
+	d := newDetectorEN().WithSuspectWords([]string{"idiot"}).WithCooccurrenceRules(ruleSet)
+	var m Matches
+
+	// Words combined
+	m = d.ScanAllProfanities("just go kill yourself!")
+	assert.Equal(t, &Match{Word: "self-harm", Start: 8, End: 21, WordType: WordTypeProfanity,
+		Text: []rune("kill yourself"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, 1, len(m))
+	assert.True(t, m[0].Cooccurrence)
+	assert.Equal(t, 8, m[0].ByteStart)
+	assert.Equal(t, 21, m[0].ByteEnd)
+
+	m = d.ScanAllProfanities("I will KILL U")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "threat", m[0].Word)
+	assert.True(t, m[0].IsSuspect())
+	assert.Equal(t, "KILL U", string(m[0].Text))
+
+	// Harmless text is not profane
+	for _, text := range []string{"can you cut the cake", "I'll shoot you a message", "you cut your hair",
+		"did you hurt yourself?", "hang on, I'll call you"} {
+		assert.False(t, d.ScanAllProfanities(text).HasProfaneMatch(), text)
+		censored, _ := d.Censor(text)
+		assert.Equal(t, text, censored)
+	}
+
+	// Suspect match escalated and replaced
+	m = d.ScanAllProfanities("you are an idiot")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "insult", m[0].Word)
+	assert.True(t, m[0].IsProfane())
+	assert.Equal(t, "you are an idiot", string(m[0].Text))
+
+	// Out of the window
+	m = d.ScanAllProfanities("you know that guy, he is an idiot")
+	assert.Equal(t, 1, len(m))
+	assert.True(t, m[0].IsSuspect())
+	assert.Equal(t, 0, len(d.ScanAllProfanities("kill some time with you")))
+
+	// One word doesn't satisfy two terms
+	assert.Equal(t, 0, len(d.ScanAllProfanities("yourself")))
+
+	// Profane matches are kept
+	m = d.ScanAllProfanities("fuck you idiot")
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.Equal(t, "insult", m[1].Word)
+
+	// Rules are applied when scanning for the first profanity as well
+	assert.True(t, d.IsProfane("you are an idiot"))
+	assert.True(t, d.IsProfane("just go kill yourself!"))
+	m = d.ScanProfanity("you idiot, fuck and shit")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "insult", m[0].Word)
+	m = d.ScanProfanity("fuck you idiot")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.False(t, d.IsProfane("I will KILL U"))
+	assert.Equal(t, 0, len(newDetectorEN().ScanAllProfanities("go kill yourself")))
+}
+
+func Test_Scan_Cooccurrence_CustomRules(t *testing.T) {
+	d := newDetectorEN().WithSuspectWords([]string{"stupid"}).WithCooccurrenceRules(&CooccurrenceRuleSet{
+		Rules: []CooccurrenceRule{
+			{Label: "rude", Terms: []string{"shut", "up", "@suspect"}, Window: 4, WordType: WordTypeSuspect},
+		},
+	})
+	m := d.ScanAllProfanities("shut up, stupid")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "rude", m[0].Word)
+	assert.True(t, m[0].IsSuspect())
+	assert.Equal(t, "shut up, stupid", string(m[0].Text))
+	assert.Equal(t, 0, len(d.ScanAllProfanities("shut up")))
+}
@@ -163,4 +163,14 @@ var DefaultFalsePositives = []string{
 	"wharfage",
 }
 
+// ReversedFalsePositives are words which are only false positives when detecting reversed text
+//
+// nolint
+var ReversedFalsePositives = []string{
+	"esra", // reversed: arse
+	"lana", // reversed: anal
+	"parc", // reversed: crap
+	"spin", // reversed: nips
+}
+
 var DefaultSuspects = []string{}
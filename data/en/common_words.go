@@ -0,0 +1,524 @@
+package en
+
+// DefaultCommonWords contains common English words, they are used to check whether derived forms
+// of dictionary words (such as consonant skeletons) are ambiguous
+var DefaultCommonWords = []string{
+	"a",
+	"about",
+	"above",
+	"across",
+	"act",
+	"add",
+	"after",
+	"again",
+	"against",
+	"age",
+	"ago",
+	"air",
+	"all",
+	"almost",
+	"alone",
+	"along",
+	"already",
+	"also",
+	"always",
+	"am",
+	"among",
+	"an",
+	"and",
+	"another",
+	"answer",
+	"any",
+	"anyone",
+	"anything",
+	"are",
+	"area",
+	"around",
+	"as",
+	"ask",
+	"at",
+	"away",
+	"back",
+	"bad",
+	"base",
+	"bat",
+	"batch",
+	"be",
+	"beach",
+	"because",
+	"become",
+	"bed",
+	"been",
+	"before",
+	"began",
+	"begin",
+	"behind",
+	"being",
+	"believe",
+	"best",
+	"better",
+	"between",
+	"big",
+	"bit",
+	"black",
+	"blood",
+	"blue",
+	"body",
+	"book",
+	"both",
+	"box",
+	"boy",
+	"bring",
+	"brother",
+	"build",
+	"business",
+	"but",
+	"buy",
+	"by",
+	"call",
+	"came",
+	"can",
+	"cant",
+	"car",
+	"care",
+	"carry",
+	"case",
+	"cat",
+	"catch",
+	"cause",
+	"center",
+	"change",
+	"check",
+	"child",
+	"city",
+	"class",
+	"clear",
+	"close",
+	"coke",
+	"cold",
+	"come",
+	"common",
+	"cook",
+	"could",
+	"count",
+	"country",
+	"course",
+	"cumin",
+	"cut",
+	"dark",
+	"day",
+	"dead",
+	"deal",
+	"death",
+	"deck",
+	"dice",
+	"did",
+	"die",
+	"different",
+	"dig",
+	"do",
+	"dock",
+	"does",
+	"dog",
+	"done",
+	"door",
+	"down",
+	"draw",
+	"dream",
+	"dress",
+	"drink",
+	"drive",
+	"duck",
+	"during",
+	"each",
+	"early",
+	"earth",
+	"east",
+	"eat",
+	"end",
+	"enough",
+	"even",
+	"ever",
+	"every",
+	"eye",
+	"face",
+	"fact",
+	"fail",
+	"fair",
+	"fake",
+	"fall",
+	"family",
+	"far",
+	"fast",
+	"father",
+	"feel",
+	"feet",
+	"few",
+	"field",
+	"fight",
+	"fill",
+	"find",
+	"fine",
+	"fire",
+	"first",
+	"fish",
+	"fit",
+	"five",
+	"fix",
+	"floor",
+	"fly",
+	"fog",
+	"follow",
+	"food",
+	"foot",
+	"for",
+	"force",
+	"form",
+	"four",
+	"free",
+	"friend",
+	"from",
+	"front",
+	"full",
+	"fun",
+	"game",
+	"gave",
+	"get",
+	"girl",
+	"give",
+	"go",
+	"god",
+	"gold",
+	"gone",
+	"good",
+	"got",
+	"great",
+	"green",
+	"ground",
+	"group",
+	"grow",
+	"gum",
+	"had",
+	"half",
+	"hand",
+	"happen",
+	"hard",
+	"has",
+	"hat",
+	"have",
+	"he",
+	"head",
+	"hear",
+	"heart",
+	"heat",
+	"help",
+	"her",
+	"here",
+	"high",
+	"him",
+	"his",
+	"hit",
+	"hold",
+	"home",
+	"hope",
+	"horse",
+	"hot",
+	"hour",
+	"house",
+	"how",
+	"however",
+	"hundred",
+	"idea",
+	"if",
+	"important",
+	"in",
+	"into",
+	"is",
+	"it",
+	"its",
+	"job",
+	"just",
+	"keep",
+	"kept",
+	"kind",
+	"king",
+	"know",
+	"land",
+	"large",
+	"last",
+	"late",
+	"later",
+	"laugh",
+	"lead",
+	"learn",
+	"least",
+	"leave",
+	"left",
+	"less",
+	"let",
+	"letter",
+	"life",
+	"light",
+	"like",
+	"line",
+	"list",
+	"little",
+	"live",
+	"long",
+	"look",
+	"lose",
+	"lost",
+	"lot",
+	"love",
+	"low",
+	"luck",
+	"made",
+	"main",
+	"make",
+	"man",
+	"many",
+	"map",
+	"mark",
+	"may",
+	"me",
+	"mean",
+	"meet",
+	"men",
+	"might",
+	"mind",
+	"miss",
+	"money",
+	"month",
+	"more",
+	"morning",
+	"most",
+	"mother",
+	"move",
+	"much",
+	"must",
+	"my",
+	"name",
+	"near",
+	"need",
+	"never",
+	"new",
+	"next",
+	"night",
+	"no",
+	"north",
+	"not",
+	"note",
+	"nothing",
+	"now",
+	"number",
+	"of",
+	"off",
+	"often",
+	"oh",
+	"old",
+	"on",
+	"once",
+	"one",
+	"only",
+	"open",
+	"or",
+	"order",
+	"other",
+	"our",
+	"out",
+	"over",
+	"own",
+	"page",
+	"paper",
+	"part",
+	"party",
+	"pass",
+	"past",
+	"pay",
+	"people",
+	"perhaps",
+	"person",
+	"pick",
+	"piece",
+	"pitch",
+	"place",
+	"plan",
+	"play",
+	"point",
+	"poor",
+	"post",
+	"power",
+	"put",
+	"question",
+	"quick",
+	"quite",
+	"race",
+	"rain",
+	"ran",
+	"reach",
+	"read",
+	"ready",
+	"real",
+	"red",
+	"rest",
+	"right",
+	"river",
+	"road",
+	"rock",
+	"room",
+	"round",
+	"rule",
+	"run",
+	"said",
+	"same",
+	"sat",
+	"saw",
+	"say",
+	"school",
+	"sea",
+	"second",
+	"see",
+	"seed",
+	"seem",
+	"seen",
+	"self",
+	"sell",
+	"send",
+	"sent",
+	"set",
+	"shall",
+	"she",
+	"sheet",
+	"ship",
+	"shirt",
+	"shoot",
+	"shop",
+	"short",
+	"shot",
+	"should",
+	"show",
+	"shut",
+	"side",
+	"sign",
+	"since",
+	"sing",
+	"sit",
+	"six",
+	"size",
+	"sky",
+	"small",
+	"so",
+	"some",
+	"son",
+	"song",
+	"soon",
+	"sound",
+	"south",
+	"space",
+	"speak",
+	"stand",
+	"star",
+	"start",
+	"state",
+	"stay",
+	"step",
+	"still",
+	"stop",
+	"story",
+	"street",
+	"strong",
+	"such",
+	"sun",
+	"sure",
+	"table",
+	"take",
+	"talk",
+	"team",
+	"tell",
+	"ten",
+	"than",
+	"that",
+	"the",
+	"their",
+	"them",
+	"then",
+	"there",
+	"these",
+	"they",
+	"thing",
+	"think",
+	"this",
+	"those",
+	"though",
+	"thought",
+	"three",
+	"through",
+	"time",
+	"to",
+	"today",
+	"together",
+	"told",
+	"too",
+	"took",
+	"top",
+	"toward",
+	"town",
+	"tree",
+	"true",
+	"try",
+	"turn",
+	"two",
+	"under",
+	"until",
+	"up",
+	"upon",
+	"us",
+	"use",
+	"very",
+	"voice",
+	"wait",
+	"walk",
+	"wall",
+	"want",
+	"war",
+	"warm",
+	"was",
+	"watch",
+	"water",
+	"way",
+	"we",
+	"week",
+	"well",
+	"went",
+	"were",
+	"west",
+	"what",
+	"when",
+	"where",
+	"which",
+	"while",
+	"white",
+	"who",
+	"whole",
+	"why",
+	"will",
+	"wind",
+	"window",
+	"witch",
+	"with",
+	"within",
+	"without",
+	"woman",
+	"wood",
+	"word",
+	"work",
+	"world",
+	"would",
+	"write",
+	"year",
+	"yes",
+	"yet",
+	"you",
+	"young",
+	"your",
+}
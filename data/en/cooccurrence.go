@@ -0,0 +1,18 @@
+package en
+
+// DefaultCooccurrenceRules are co-occurrence rules in JSON, they can be loaded with
+// ParseCooccurrenceRules of the profanityout package.
+// NOTE: only "kill yourself" is escalated to profanity, other violent words near "you" are common
+// in harmless text ("did you hurt yourself?"), so threats are suspects.
+const DefaultCooccurrenceRules = `{
+  "categories": {
+    "you": ["you", "u", "ya", "your", "ur", "youre", "yourself", "urself"],
+    "self": ["yourself", "urself", "yourselves"],
+    "violence": ["kill", "stab", "hurt"]
+  },
+  "rules": [
+    {"label": "self-harm", "terms": ["kill", "@self"], "window": 3},
+    {"label": "threat", "terms": ["@violence", "@you"], "window": 2, "wordType": "suspect"},
+    {"label": "insult", "terms": ["@suspect", "@you"]}
+  ]
+}`
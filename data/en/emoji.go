@@ -0,0 +1,74 @@
+package en
+
+// NOTE: skin tones and variation selectors of emoji are ignored when matching,
+// so they don't need to be included in the lists.
+
+var EmojiProfanities = []string{
+	"🖕",  // middle finger
+	"🍆💦", // eggplant + sweat droplets
+	"🍑💦", // peach + sweat droplets
+	"👉👌", // sexual gesture
+	"👌👈", // sexual gesture
+	"🍆🍑", // eggplant + peach
+	"🍆👅", // eggplant + tongue
+}
+
+var EmojiSuspects = []string{
+	"🍆", // eggplant
+	"🍑", // peach
+	"💦", // sweat droplets
+	"🐷", // pig
+	"🐖", // pig
+	"🐽", // pig nose
+	"💩", // pile of poo
+	"🤬", // face with symbols on mouth
+	"🐍", // snake
+	"🤡", // clown
+	"🐀", // rat
+}
+
+var EmojiCharacters = map[string]string{
+	// Parts of words
+	// NOTE: emoji which are words on their own (such as 🐓) are not mapped here,
+	// otherwise they'd be detected as profanities when used alone.
+	"🦆": "uck", // duck -> f🦆
+
+	// Letters
+	"🅰": "a",
+	"🅱": "b",
+	"🅾": "o",
+	"🅿": "p",
+	"❌": "x",
+	"⭕": "o",
+	"💲": "s",
+	"❗": "i",
+	"❕": "i",
+
+	// Regional indicators
+	"🇦": "a",
+	"🇧": "b",
+	"🇨": "c",
+	"🇩": "d",
+	"🇪": "e",
+	"🇫": "f",
+	"🇬": "g",
+	"🇭": "h",
+	"🇮": "i",
+	"🇯": "j",
+	"🇰": "k",
+	"🇱": "l",
+	"🇲": "m",
+	"🇳": "n",
+	"🇴": "o",
+	"🇵": "p",
+	"🇶": "q",
+	"🇷": "r",
+	"🇸": "s",
+	"🇹": "t",
+	"🇺": "u",
+	"🇻": "v",
+	"🇼": "w",
+	"🇽": "x",
+	"🇾": "y",
+	"🇿": "z",
+}
@@ -0,0 +1,49 @@
+package en
+
+// UsernameFalsePositives contains false positives for username validation. Dictionary words are
+// matched anywhere inside usernames, so words containing them need to be listed here.
+//
+// nolint
+var UsernameFalsePositives = []string{
+	"abutt",
+	"analog",
+	"arsenal",
+	"arsenic",
+	"assail",
+	"assault",
+	"assemb", // assemble, assembly
+	"assess",
+	"asset",
+	"babcock",
+	"brass",
+	"crass",
+	"cocoon",
+	"cockatoo",
+	"cockerel",
+	"cockney",
+	"cockpit",
+	"dickens",
+	"dickinson",
+	"essex",
+	"hancock",
+	"hitchcock",
+	"manuscript",
+	"muffin",
+	"muffle",
+	"parse",
+	"peacock",
+	"pissarro",
+	"raccoon",
+	"rebuttal",
+	"shuttlecock",
+	"sparse",
+	"sturd", // sturdy
+	"sextant",
+	"sextet",
+	"sexton",
+	"titan",
+	"turnips",
+	"tycoon",
+	"uranus",
+	"woodcock",
+}
@@ -1,5 +1,11 @@
 package profanityout
 
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
 type ProfanityDetector struct {
 	settings            DetectorSettings
 	specialCharacters   map[rune]rune
@@ -7,6 +13,27 @@ type ProfanityDetector struct {
 	wildcardCharacters  map[rune]rune
 	profanityTree       *tree
 	falsePositiveTree   *tree
+	reversedTree        *tree
+	// false positives when detecting reversed text, false positive words are added reversed
+	reversedFalsePositiveTree *tree
+
+	repeatSensitiveWords map[string]struct{}
+	emojiTree            *tree
+	phoneticIndex        phoneticIndex
+	commonWords          map[string]struct{}
+	profaneWords         []string // profane words in order of adding, used to derive consonant skeletons
+	skeletonMinLength    int      // consonant skeletons are derived when this is greater than 0
+	skeletonTree         *tree    // consonant skeletons, built on first use (see consonantSkeletonTree)
+	skeletonMu           sync.Mutex
+
+	phrases              []*phrase // profane and suspect phrases
+	falsePositivePhrases []*phrase
+	cooccurrenceRules    *cooccurrenceRules
+	wordWeights          map[string]float64 // weights of words in scores keyed by lowercase words
+
+	// false positives for username validation, this contains all normal false positives as well
+	usernameFalsePositiveTree *tree
+	usernameOptions           []DetectorOption
 }
 
 func NewProfanityDetector() *ProfanityDetector {
@@ -16,13 +43,26 @@ func NewProfanityDetector() *ProfanityDetector {
 			SanitizeLeetSpeak:          true,
 			SanitizeSpaces:             true,
 			SanitizeRepeatedCharacters: true,
+			SanitizeRepeatedLeetSpeak:  true,
 			SanitizeWildcardCharacters: true,
 			SanitizeAccents:            true,
+			PhoneticMatchType:          WordTypeSuspect,
 			ConfidenceCalculator:       confidenceCalculator,
 			CensorCharacter:            '*',
+			FieldSeparator:             " ",
+			ScoreProfaneWeight:         1,
+			ScoreSuspectWeight:         0.3, //nolint:mnd
+			ScoreObfuscationWeight:     0.5, //nolint:mnd
+			ScoreRepetitionDecay:       0.5, //nolint:mnd
+			ScoreLengthReference:       20,  //nolint:mnd
 		},
 		profanityTree:     newTree(),
 		falsePositiveTree: newTree(),
+		reversedTree:      newTree(),
+		phoneticIndex:     phoneticIndex{},
+
+		reversedFalsePositiveTree: newTree(),
+		usernameFalsePositiveTree: newTree(),
 	}
 }
 
@@ -30,7 +70,11 @@ func NewProfanityDetector() *ProfanityDetector {
 func (d *ProfanityDetector) WithProfaneWords(profaneWords []string) *ProfanityDetector {
 	for _, word := range profaneWords {
 		d.profanityTree.Add(word, WordTypeProfanity)
+		d.reversedTree.AddReversed(word, WordTypeProfanity)
+		d.phoneticIndex.Add(word, WordTypeProfanity)
 	}
+	d.profaneWords = append(d.profaneWords, profaneWords...)
+	d.skeletonTree = nil
 	return d
 }
 
@@ -38,7 +82,57 @@ func (d *ProfanityDetector) WithProfaneWords(profaneWords []string) *ProfanityDe
 func (d *ProfanityDetector) WithSuspectWords(suspectWords []string) *ProfanityDetector {
 	for _, word := range suspectWords {
 		d.profanityTree.Add(word, WordTypeSuspect)
+		d.reversedTree.AddReversed(word, WordTypeSuspect)
+		d.phoneticIndex.Add(word, WordTypeSuspect)
 	}
+	d.skeletonTree = nil
+	return d
+}
+
+// WithProfanePhrases sets profane phrases made of words. A word can have alternatives ("kill|hurt") and
+// a trailing '*' matching words with the prefix ("fuck*"). A gap rule "~N" between two words allows up to
+// N other words between them. Spaces and punctuation between the words are always allowed.
+// A phrase found produces one match covering the whole phrase.
+//
+// For instance, "go ~1 kill|hurt yourself" matches "go kill yourself" and "Go and hurt... yourself!".
+func (d *ProfanityDetector) WithProfanePhrases(phrases []string) *ProfanityDetector {
+	return d.addPhrases(phrases, WordTypeProfanity)
+}
+
+// WithSuspectPhrases sets suspect phrases, see WithProfanePhrases for the syntax
+func (d *ProfanityDetector) WithSuspectPhrases(phrases []string) *ProfanityDetector {
+	return d.addPhrases(phrases, WordTypeSuspect)
+}
+
+// WithFalsePositivePhrases sets false positive phrases, matches of profane and suspect phrases
+// overlapping them are ignored. See WithProfanePhrases for the syntax.
+//
+// For instance, "kill|killed it" prevents "go kill ~1 it" from matching "go kill it on stage".
+func (d *ProfanityDetector) WithFalsePositivePhrases(phrases []string) *ProfanityDetector {
+	return d.addPhrases(phrases, WordTypeFalsePositive)
+}
+
+func (d *ProfanityDetector) addPhrases(phrases []string, wordType WordType) *ProfanityDetector {
+	for _, text := range phrases {
+		p := parsePhrase(text, wordType)
+		if p == nil {
+			continue
+		}
+		if wordType == WordTypeFalsePositive {
+			d.falsePositivePhrases = append(d.falsePositivePhrases, p)
+		} else {
+			d.phrases = append(d.phrases, p)
+		}
+	}
+	return d
+}
+
+// WithCooccurrenceRules sets co-occurrence rules. Words and suspect matches found near each other
+// according to a rule are combined into a new match labeled with the rule, and the suspect matches
+// combined are replaced by it. The whole text is scanned even when scanning for the first profanity.
+// Rule sets can be loaded from JSON with ParseCooccurrenceRules.
+func (d *ProfanityDetector) WithCooccurrenceRules(ruleSet *CooccurrenceRuleSet) *ProfanityDetector {
+	d.cooccurrenceRules = newCooccurrenceRules(ruleSet)
 	return d
 }
 
@@ -46,6 +140,64 @@ func (d *ProfanityDetector) WithSuspectWords(suspectWords []string) *ProfanityDe
 func (d *ProfanityDetector) WithFalsePositiveWords(falsePositives []string) *ProfanityDetector {
 	for _, word := range falsePositives {
 		d.falsePositiveTree.Add(word, WordTypeFalsePositive)
+		d.reversedFalsePositiveTree.AddReversed(word, WordTypeFalsePositive)
+		d.usernameFalsePositiveTree.Add(word, WordTypeFalsePositive)
+	}
+	d.skeletonTree = nil
+	return d
+}
+
+// WithReversedFalsePositiveWords sets words which are false positives only when detecting reversed text,
+// they are not reversed.
+//
+// For instance, "spin" is not detected as "nips" written backwards when it's set.
+func (d *ProfanityDetector) WithReversedFalsePositiveWords(falsePositives []string) *ProfanityDetector {
+	for _, word := range falsePositives {
+		d.reversedFalsePositiveTree.Add(word, WordTypeFalsePositive)
+	}
+	return d
+}
+
+// WithCommonWords sets common words of the language. They are not matched by themselves, but used to
+// check whether derived forms of dictionary words are ambiguous.
+func (d *ProfanityDetector) WithCommonWords(commonWords []string) *ProfanityDetector {
+	if d.commonWords == nil {
+		d.commonWords = make(map[string]struct{}, len(commonWords))
+	}
+	for _, word := range commonWords {
+		d.commonWords[strings.ToLower(normalizeAsNFC(word))] = struct{}{}
+	}
+	d.skeletonTree = nil
+	return d
+}
+
+// WithConsonantSkeletons allows deriving of consonant skeletons (vowels removed) from profane words.
+// The derived forms are matched as their base words. Skeletons shorter than the min length (default: 3)
+// or ambiguous with common words, false positives or other dictionary words are skipped.
+//
+// For instance, "fck" and "cnt" might be detected as "fuck" and "cunt".
+func (d *ProfanityDetector) WithConsonantSkeletons(minLength int) *ProfanityDetector {
+	if minLength <= 0 {
+		minLength = defaultConsonantSkeletonMinLength
+	}
+	d.skeletonMinLength = minLength
+	d.skeletonTree = nil
+	return d
+}
+
+// WithRepeatSensitiveWords sets words of the dictionaries whose repeated characters are meaningful.
+// These words are only matched when no repeated characters were collapsed in the input.
+//
+// For instance, when "nob" is set, "noob" won't be detected as "nob".
+func (d *ProfanityDetector) WithRepeatSensitiveWords(words []string) *ProfanityDetector {
+	if d.repeatSensitiveWords == nil {
+		d.repeatSensitiveWords = make(map[string]struct{}, len(words))
+	}
+	for _, word := range words {
+		// Variants of words with wildcards are added the same way as to the dictionaries
+		for _, variant := range buildWordListHandleWildcard(strings.Trim(normalizeAsNFC(word), "*")) {
+			d.repeatSensitiveWords[variant] = struct{}{}
+		}
 	}
 	return d
 }
@@ -68,6 +220,20 @@ func (d *ProfanityDetector) WithWildcardCharacters(wildcardChars map[rune]rune)
 	return d
 }
 
+// WithEmojiCharacters sets emoji substitution map. Keys are emoji or sequences of emoji and
+// values are the letters they stand for. Skin tones and variation selectors are ignored.
+//
+// For instance, when "🦆" is mapped to "uck", "f🦆" would be detected as "fuck".
+func (d *ProfanityDetector) WithEmojiCharacters(emojiChars map[string]string) *ProfanityDetector {
+	if d.emojiTree == nil {
+		d.emojiTree = newTree()
+	}
+	for emoji, replacement := range emojiChars {
+		d.emojiTree.addPath(removeEmojiModifiers(normalizeAsNFC(emoji)), replacement, WordTypeProfanity, wordFlagDefault)
+	}
+	return d
+}
+
 // WithSanitizeLeetSpeak allows configuring whether the sanitization process should also
 // take into account leetspeak.
 //
@@ -101,6 +267,16 @@ func (d *ProfanityDetector) WithSanitizeAccents(sanitize bool) *ProfanityDetecto
 	return d
 }
 
+// WithSanitizeEmoji allows configuring of whether the sanitization process should also take into account
+// emoji. Emoji clusters are treated as separate words, skin tones and variation selectors are ignored,
+// and emoji are replaced by letters as configured by WithEmojiCharacters (default: false).
+//
+// For instance, "🖕🏽" might be detected as "🖕" and "f🦆" might be sanitized to "fuck".
+func (d *ProfanityDetector) WithSanitizeEmoji(sanitize bool) *ProfanityDetector {
+	d.settings.SanitizeEmoji = sanitize
+	return d
+}
+
 // WithSanitizeRepeatedCharacters allows configuring of whether the sanitization process should also take
 // into account repeated characters.
 //
@@ -110,6 +286,24 @@ func (d *ProfanityDetector) WithSanitizeRepeatedCharacters(sanitize bool) *Profa
 	return d
 }
 
+// WithRepeatedCharactersMinLength sets the minimum length of a run of the same character
+// to be collapsed when sanitizing repeated characters (default: 2).
+//
+// For instance, when this is 3, "fuuuck" is sanitized to "fuck", but "fuuck" is kept as is.
+func (d *ProfanityDetector) WithRepeatedCharactersMinLength(minLength int) *ProfanityDetector {
+	d.settings.RepeatedCharactersMinLength = minLength
+	return d
+}
+
+// WithSanitizeRepeatedLeetSpeak allows configuring of whether repeated leet speak and special characters
+// are collapsed the same way as repeated normal characters.
+//
+// For instance, "$$hit" might be sanitized to "shit" as "$" is a leet speak character of "s".
+func (d *ProfanityDetector) WithSanitizeRepeatedLeetSpeak(sanitize bool) *ProfanityDetector {
+	d.settings.SanitizeRepeatedLeetSpeak = sanitize
+	return d
+}
+
 // WithSanitizeWildcardCharacters allows configuring of whether the sanitization process should also take
 // into account wildcard characters.
 //
@@ -119,16 +313,252 @@ func (d *ProfanityDetector) WithSanitizeWildcardCharacters(sanitize bool) *Profa
 	return d
 }
 
+// WithDetectReversed allows configuring of whether words written backwards should also be detected.
+// All sanitization is applied to the reversed words the same way. Matches of reversed words have
+// the `Reversed` flag set.
+//
+// For instance, "kcuf" and "hct!b" might be detected as "fuck" and "bitch".
+func (d *ProfanityDetector) WithDetectReversed(detect bool) *ProfanityDetector {
+	d.settings.DetectReversed = detect
+	return d
+}
+
+// WithFuzzyMaxEditDistance allows configuring of whether misspelled words should also be detected.
+// When this is greater than 0, a word is matched when it can be converted to a dictionary word with
+// at most this number of insertions, deletions, substitutions or transpositions of characters.
+// The edit distance is reported by `Match.EditDistance`, a ConfidenceCalculator can be used to weigh it.
+//
+// For instance, "fcuk" and "biotch" might be detected as "fuck" and "biatch".
+func (d *ProfanityDetector) WithFuzzyMaxEditDistance(maxDistance int) *ProfanityDetector {
+	d.settings.FuzzyMaxEditDistance = maxDistance
+	return d
+}
+
+// WithFuzzyMinWordLength sets the minimum length of dictionary words to be matched in fuzzy matching mode
+// (default: 4). Short words are too easily matched by normal words with small edits.
+func (d *ProfanityDetector) WithFuzzyMinWordLength(minLength int) *ProfanityDetector {
+	d.settings.FuzzyMinWordLength = minLength
+	return d
+}
+
+// WithDetectPhonetic allows configuring of whether words which sound the same as dictionary words
+// should also be detected. Words are compared by their phonetic keys (a simplified Metaphone for English).
+// Matches have the `Phonetic` flag set.
+//
+// For instance, "phuk" and "sheeit" might be detected as "fuck" and "shit".
+func (d *ProfanityDetector) WithDetectPhonetic(detect bool) *ProfanityDetector {
+	d.settings.DetectPhonetic = detect
+	return d
+}
+
+// WithPhoneticMatchType sets the word type of phonetic matches (default: WordTypeSuspect).
+// When it's 0, the word type of the dictionary word is used.
+func (d *ProfanityDetector) WithPhoneticMatchType(wordType WordType) *ProfanityDetector {
+	d.settings.PhoneticMatchType = wordType
+	return d
+}
+
+// WithPhoneticMinKeyLength sets the minimum length of phonetic keys to be matched (default: 3).
+// Short keys are shared by too many normal words.
+func (d *ProfanityDetector) WithPhoneticMinKeyLength(minLength int) *ProfanityDetector {
+	d.settings.PhoneticMinKeyLength = minLength
+	return d
+}
+
 // WithProcessInputAsHTML allows configuring of whether the sanitization process should also take
 // into account HTML content.
 //
-// For instance, all HTML tags in the input will be removed and all HTMl entities will be replaced
-// by real characters (for example, &gt; will be replaced with '>').
+// For instance, all HTML tags in the input will be removed and all HTML5 character references will be
+// replaced by real characters (for example, &gt; and &#x3e; will be replaced with '>'). Block tags and "<br>"
+// end words, comments, scripts and styles are not scanned, and values of the attributes alt, title and
+// placeholder are scanned as separate words.
 func (d *ProfanityDetector) WithProcessInputAsHTML(asHTML bool) *ProfanityDetector {
 	d.settings.ProcessInputAsHTML = asHTML
 	return d
 }
 
+// WithProcessInputAsMarkdown allows configuring of whether the input should be processed as Markdown.
+// Emphasis markers and link brackets are skipped, block markers, link destinations and code are
+// not scanned, and Censor keeps the syntax intact.
+//
+// For instance, "f**u**ck" and "sh_i_t" might be detected as "fuck" and "shit".
+func (d *ProfanityDetector) WithProcessInputAsMarkdown(asMarkdown bool) *ProfanityDetector {
+	d.settings.ProcessInputAsMarkdown = asMarkdown
+	return d
+}
+
+// WithProcessInputAsBBCode allows configuring of whether the input should be processed as BBCode.
+// Formatting tags are skipped, block tags separate words, content of code, image and video tags
+// is not scanned, and Censor keeps the markup intact.
+//
+// For instance, "[b]fu[/b]ck" might be detected as "fuck".
+func (d *ProfanityDetector) WithProcessInputAsBBCode(asBBCode bool) *ProfanityDetector {
+	d.settings.ProcessInputAsBBCode = asBBCode
+	return d
+}
+
+// WithBBCodeExcludeQuotes allows configuring of whether quoted content ([quote]...[/quote]) should be
+// excluded from scanning in BBCode mode, as it contains words of other users.
+func (d *ProfanityDetector) WithBBCodeExcludeQuotes(exclude bool) *ProfanityDetector {
+	d.settings.BBCodeExcludeQuotes = exclude
+	return d
+}
+
+// WithSegmentWords allows configuring of whether the input should be split into words for languages
+// which don't use spaces between words such as Chinese, Japanese or Thai. Word edges are then treated
+// the same way as spaces.
+//
+// By default, the text is split by the longest matching words in the profanity and false positive
+// dictionaries only, so a false positive overlapping a profanity next to it may hide the profanity.
+// Use WithWordSegmenter to provide a better segmenter, such as one with a full dictionary of the language.
+func (d *ProfanityDetector) WithSegmentWords(segment bool) *ProfanityDetector {
+	d.settings.SegmentWords = segment
+	return d
+}
+
+// WithWordSegmenter sets custom word segmenter, this takes effect only when WithSegmentWords is on
+func (d *ProfanityDetector) WithWordSegmenter(segmenter WordSegmenter) *ProfanityDetector {
+	d.settings.WordSegmenter = segmenter
+	return d
+}
+
+// WithSplitCompoundWords allows configuring of whether compound words should be split into words.
+// Hashtags, camelCase, snake_case, trailing numbers and concatenated words are split, word edges are
+// then treated the same way as spaces. Concatenated words are only split when all parts are found
+// in the dictionaries or the common words (see WithCommonWords).
+//
+// For instance, "BigAssDog" and "#fuckthis" might be detected as "ass" and "fuck".
+func (d *ProfanityDetector) WithSplitCompoundWords(split bool) *ProfanityDetector {
+	d.settings.SplitCompoundWords = split
+	return d
+}
+
+// WithMatchEmbeddedWords allows configuring of whether dictionary words should also be matched
+// inside other words. The space requirements of dictionary words are ignored, but false positives
+// still take precedence.
+//
+// For instance, "xfuckx" might be detected as "fuck".
+func (d *ProfanityDetector) WithMatchEmbeddedWords(match bool) *ProfanityDetector {
+	d.settings.MatchEmbeddedWords = match
+	return d
+}
+
+// WithUsernameFalsePositiveWords sets false positive words which are only used by ValidateUsername.
+// As dictionary words are matched anywhere inside usernames, words such as "peacock" need to be listed.
+func (d *ProfanityDetector) WithUsernameFalsePositiveWords(falsePositives []string) *ProfanityDetector {
+	for _, word := range falsePositives {
+		d.usernameFalsePositiveTree.Add(word, WordTypeFalsePositive)
+	}
+	d.skeletonTree = nil
+	return d
+}
+
+// WithUsernameOptions sets the options used by ValidateUsername, they are applied on top of
+// the username profile (see ValidateUsername).
+func (d *ProfanityDetector) WithUsernameOptions(options ...DetectorOption) *ProfanityDetector {
+	d.usernameOptions = append(d.usernameOptions, options...)
+	return d
+}
+
+// WithURLPolicy sets how URLs and email addresses in the input are scanned. URLs are recognized by
+// their schemes ("https://") or the "www." prefix.
+//
+// For instance, with URLPolicyHostAndPath, "https://example.com/?ref=fuck" is not detected as profane,
+// but "https://fuck.example.com" is.
+func (d *ProfanityDetector) WithURLPolicy(policy URLPolicy) *ProfanityDetector {
+	d.settings.URLPolicy = policy
+	return d
+}
+
+// WithURLPercentDecode allows configuring of whether percent-encoded characters in URLs and email
+// addresses should be decoded before scanning.
+//
+// For instance, "https://example.com/%66uck" might be detected as "fuck".
+func (d *ProfanityDetector) WithURLPercentDecode(decode bool) *ProfanityDetector {
+	d.settings.URLPercentDecode = decode
+	return d
+}
+
+// WithStreamLookback sets the number of characters kept when scanning a stream to detect
+// matches crossing chunk boundaries (default: calculated from the longest dictionary word).
+func (d *ProfanityDetector) WithStreamLookback(lookback int) *ProfanityDetector {
+	d.settings.StreamLookback = lookback
+	return d
+}
+
+// WithJSONScanKeys allows configuring of whether object keys should be scanned in JSON documents
+func (d *ProfanityDetector) WithJSONScanKeys(scanKeys bool) *ProfanityDetector {
+	d.settings.JSONScanKeys = scanKeys
+	return d
+}
+
+// WithJSONIncludePaths sets JSON Pointers of the values scanned in JSON documents, all values are scanned
+// when it's empty. Descendants of the values are scanned too and a "*" segment matches any key or index.
+//
+// For instance, "/comments/*/text" includes the text of all comments.
+func (d *ProfanityDetector) WithJSONIncludePaths(paths ...string) *ProfanityDetector {
+	d.settings.JSONIncludePaths = paths
+	return d
+}
+
+// WithJSONExcludePaths sets JSON Pointers of the values not scanned in JSON documents,
+// they take precedence over the included paths
+func (d *ProfanityDetector) WithJSONExcludePaths(paths ...string) *ProfanityDetector {
+	d.settings.JSONExcludePaths = paths
+	return d
+}
+
+// WithFieldSeparator sets the separator joining the texts of fields scanned together (default: " ").
+// With the default separator, words split across fields are detected when spaces are sanitized.
+func (d *ProfanityDetector) WithFieldSeparator(separator string) *ProfanityDetector {
+	d.settings.FieldSeparator = separator
+	return d
+}
+
+// WithWordWeights sets the weights of words in scores calculated by ScoreText. Keys are dictionary words,
+// phrases or labels of co-occurrence rules, matches of other words have the weights of their types.
+func (d *ProfanityDetector) WithWordWeights(weights map[string]float64) *ProfanityDetector {
+	if d.wordWeights == nil {
+		d.wordWeights = make(map[string]float64, len(weights))
+	}
+	for word, weight := range weights {
+		d.wordWeights[strings.ToLower(normalizeAsNFC(word))] = weight
+	}
+	return d
+}
+
+// WithScoreProfaneWeight sets the weight of profane matches in scores (default: 1)
+func (d *ProfanityDetector) WithScoreProfaneWeight(weight float64) *ProfanityDetector {
+	d.settings.ScoreProfaneWeight = weight
+	return d
+}
+
+// WithScoreSuspectWeight sets the weight of suspect matches in scores (default: 0.3)
+func (d *ProfanityDetector) WithScoreSuspectWeight(weight float64) *ProfanityDetector {
+	d.settings.ScoreSuspectWeight = weight
+	return d
+}
+
+// WithScoreObfuscationWeight sets the bonus of fully obfuscated matches relative to their weights (default: 0.5)
+func (d *ProfanityDetector) WithScoreObfuscationWeight(weight float64) *ProfanityDetector {
+	d.settings.ScoreObfuscationWeight = weight
+	return d
+}
+
+// WithScoreRepetitionDecay sets the factor multiplying the value of a match for each previous match
+// of the same word (default: 0.5). 1 counts repeated words fully, 0 ignores them.
+func (d *ProfanityDetector) WithScoreRepetitionDecay(decay float64) *ProfanityDetector {
+	d.settings.ScoreRepetitionDecay = decay
+	return d
+}
+
+// WithScoreLengthReference sets the number of words from which scores of longer texts are reduced
+// (default: 20, 0 disables the reduction)
+func (d *ProfanityDetector) WithScoreLengthReference(words int) *ProfanityDetector {
+	d.settings.ScoreLengthReference = words
+	return d
+}
+
 // WithConfidenceCalculator sets custom confidence calculator function
 func (d *ProfanityDetector) WithConfidenceCalculator(calculator ConfidenceCalculator) *ProfanityDetector {
 	d.settings.ConfidenceCalculator = calculator
@@ -156,7 +586,16 @@ func (d *ProfanityDetector) ScanAllProfanities(s string, options ...DetectorOpti
 	return d.newScanner(true, options...).scan(s)
 }
 
-// Censor scans for all profanities and censors all of them if found
+// ScanReader scans for all profanities in the text read from the reader.
+// The text is processed chunk by chunk with bounded memory, so this can be used for large inputs.
+// The handler is called for every match in order of position, it can return false to stop scanning.
+func (d *ProfanityDetector) ScanReader(r io.Reader, handler func(*Match) bool, options ...DetectorOption) error {
+	return d.newStreamScanner(handler, options...).readAll(r)
+}
+
+// Censor scans for all profanities and censors all of them if found.
+// When the input is processed as HTML, only text is censored and an entity is replaced by
+// one censor character, so positions of the matches may not apply to the result.
 func (d *ProfanityDetector) Censor(s string, options ...DetectorOption) (string, Matches) {
 	scanner := d.newScanner(true, options...)
 	matches := scanner.scan(s)
@@ -164,15 +603,7 @@ func (d *ProfanityDetector) Censor(s string, options ...DetectorOption) (string,
 		return s, nil
 	}
 
-	content := scanner.inputOrig
-	for _, match := range matches.GetProfaneMatches() {
-		for i := match.Start; i < match.End; i++ {
-			if content[i] != ' ' {
-				content[i] = scanner.settings.CensorCharacter
-			}
-		}
-	}
-	return string(content), matches
+	return scanner.censorInput(matches), matches
 }
 
 func (d *ProfanityDetector) newScanner(findAllMatches bool, options ...DetectorOption) *scanner {
@@ -187,7 +618,20 @@ func (d *ProfanityDetector) newScanner(findAllMatches bool, options ...DetectorO
 		leetSpeakCharacters: d.leetSpeakCharacters,
 		wildcardCharacters:  d.wildcardCharacters,
 		profanityTree:       d.profanityTree,
+		skeletonTree:        d.consonantSkeletonTree(),
 		falsePositiveTree:   d.falsePositiveTree,
+		reversedTree:        d.reversedTree,
+
+		reversedFalsePositiveTree: d.reversedFalsePositiveTree,
+
+		repeatSensitiveWords: d.repeatSensitiveWords,
+		emojiTree:            d.emojiTree,
+		phoneticIndex:        d.phoneticIndex,
+		commonWords:          d.commonWords,
+		phrases:              d.phrases,
+		falsePositivePhrases: d.falsePositivePhrases,
+		cooccurrenceRules:    d.cooccurrenceRules,
+		wordWeights:          d.wordWeights,
 	}
 }
 
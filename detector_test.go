@@ -111,6 +111,68 @@ func Test_Scan_One(t *testing.T) {
 
 		m = d().WithSanitizeRepeatedCharacters(false).ScanProfanity("x-fuu_cck")
 		assert.Nil(t, m)
+
+		// Repeated characters separated by spaces
+		m = d().WithSanitizeRepeatedCharacters(true).ScanProfanity("x f u u c k")
+		assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 11, WordType: WordTypeProfanity,
+			Text: []rune("f u u c k"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	})
+
+	t.Run("Sanitize repeated char min length tests", func(t *testing.T) {
+		m = d().WithRepeatedCharactersMinLength(3).ScanProfanity("x fuuck")
+		assert.Nil(t, m)
+
+		m = d().WithRepeatedCharactersMinLength(3).ScanProfanity("x fuuuck")
+		assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 8, WordType: WordTypeProfanity,
+			Text: []rune("fuuuck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+		m = d().ScanProfanity("x f u u u c k", WithRepeatedCharactersMinLength(3))
+		assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 13, WordType: WordTypeProfanity,
+			Text: []rune("f u u u c k"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+		m = d().ScanProfanity("x f u u c k", WithRepeatedCharactersMinLength(3))
+		assert.Nil(t, m)
+
+		// Values less than 2 have the default behavior
+		m = d().WithRepeatedCharactersMinLength(0).ScanProfanity("x fuuck")
+		assert.Equal(t, "fuck", m[0].Word)
+
+		// Runs are counted the same way as scanning
+		m = d().WithProcessInputAsHTML(true).ScanProfanity("x f&#117;&#117;uck", WithRepeatedCharactersMinLength(3))
+		assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 18, WordType: WordTypeProfanity,
+			Text: []rune("f&#117;&#117;uck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	})
+
+	t.Run("Sanitize repeated leet speak tests", func(t *testing.T) {
+		m = d().WithSanitizeSpecialCharacters(false).WithSanitizeRepeatedLeetSpeak(true).ScanProfanity("x $$hit")
+		assert.Equal(t, &Match{Word: "shit", Start: 2, End: 7, WordType: WordTypeProfanity,
+			Text: []rune("$$hit"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+		m = d().WithSanitizeSpecialCharacters(false).WithSanitizeRepeatedLeetSpeak(true).ScanProfanity("x s5$hit")
+		assert.Equal(t, &Match{Word: "shit", Start: 2, End: 8, WordType: WordTypeProfanity,
+			Text: []rune("s5$hit"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+		m = d().WithSanitizeSpecialCharacters(false).WithSanitizeRepeatedLeetSpeak(false).ScanProfanity("x $$hit")
+		assert.Nil(t, m)
+	})
+
+	t.Run("Repeat sensitive word tests", func(t *testing.T) {
+		m = d().WithProfaneWords([]string{"nob"}).ScanProfanity("x noob")
+		assert.Equal(t, "nob", m[0].Word)
+
+		m = d().WithProfaneWords([]string{"nob"}).WithRepeatSensitiveWords([]string{"nob"}).ScanProfanity("x noob")
+		assert.Nil(t, m)
+
+		m = d().WithProfaneWords([]string{"nob"}).WithRepeatSensitiveWords([]string{"nob"}).ScanProfanity("x n0b")
+		assert.Equal(t, "nob", m[0].Word)
+
+		// Other words are not affected
+		m = d().WithRepeatSensitiveWords([]string{"nob"}).ScanProfanity("x fuuck")
+		assert.Equal(t, "fuck", m[0].Word)
+
+		// Words with wildcards inside
+		m = d().WithProfaneWords([]string{"f*ck"}).WithRepeatSensitiveWords([]string{"f*ck"}).ScanProfanity("x ffck")
+		assert.Nil(t, m)
 	})
 
 	t.Run("Sanitize wildcard char tests", func(t *testing.T) {
@@ -160,6 +222,30 @@ func Test_Scan_One(t *testing.T) {
 
 		m = d().WithSanitizeAccents(false).ScanProfanity("aa fúck")
 		assert.Nil(t, m)
+
+		// Decomposed accents
+		m = d().WithSanitizeAccents(true).ScanProfanity("aa fu\u0301ck x")
+		assert.Equal(t, &Match{Word: "fuck", Start: 3, End: 7, WordType: WordTypeProfanity,
+			Text: []rune("fúck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+		assert.Equal(t, 3, m[0].ByteStart)
+		assert.Equal(t, 9, m[0].ByteEnd)
+		assert.Equal(t, 8, m[0].UTF16End)
+		assert.Equal(t, "fu\u0301ck", "aa fu\u0301ck x"[m[0].ByteStart:m[0].ByteEnd])
+		m = d().WithSanitizeAccents(true).ScanProfanity("\xffe\u0301 fu\u0301ck")
+		assert.Equal(t, []int{5, 11, 4, 9}, []int{m[0].ByteStart, m[0].ByteEnd, m[0].UTF16Start, m[0].UTF16End})
+		// Only the matches are changed when censoring
+		censored, _ := d().WithSanitizeAccents(true).Censor("e\u0301 fu\u0301ck x")
+		assert.Equal(t, "e\u0301 **** x", censored)
+		censored, _ = d().WithSanitizeAccents(false).Censor("e\u0301 fuck x")
+		assert.Equal(t, "e\u0301 **** x", censored)
+
+		// Combining marks which don't compose
+		m = d().WithSanitizeAccents(true).ScanProfanity("aa f\u0336u\u0336c\u0336k\u0336 x")
+		assert.Equal(t, &Match{Word: "fuck", Start: 3, End: 11, WordType: WordTypeProfanity,
+			Text: []rune("f\u0336u\u0336c\u0336k\u0336"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+		censored, _ = d().WithSanitizeAccents(true).Censor("aa f\u0336u\u0336c\u0336k\u0336 x")
+		assert.Equal(t, "aa **** x", censored)
+		assert.Nil(t, d().WithSanitizeAccents(false).ScanProfanity("aa f\u0336u\u0336c\u0336k\u0336 x"))
 	})
 
 	t.Run("False positive tests", func(t *testing.T) {
@@ -182,6 +268,44 @@ func Test_Scan_One(t *testing.T) {
 			Text: []rune("sh!t"), HeadSpace: true, TailSpace: true}, toCmp(m[1]))
 	})
 
+	t.Run("Detect reversed tests", func(t *testing.T) {
+		m = d().WithDetectReversed(true).ScanProfanity("x kcuf")
+		assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 6, WordType: WordTypeProfanity,
+			Text: []rune("kcuf"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+		assert.True(t, m[0].Reversed)
+
+		// Sanitizers are applied the same way
+		m = d().ScanProfanity("x hct!!!b", WithDetectReversed(true))
+		assert.Equal(t, &Match{Word: "bitch", Start: 2, End: 9, WordType: WordTypeProfanity,
+			Text: []rune("hct!!!b"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+		assert.True(t, m[0].Reversed)
+
+		// Wildcards in dictionary entries are reversed too
+		m = d().WithProfaneWords([]string{"blah*"}).WithDetectReversed(true).ScanProfanity("x xhalb")
+		assert.Equal(t, &Match{Word: "blah", Start: 3, End: 7, WordType: WordTypeProfanity,
+			Text: []rune("halb"), HeadSpace: false, TailSpace: true}, toCmp(m[0]))
+
+		// Forward matches take precedence
+		m = d().WithDetectReversed(true).ScanProfanity("x poop")
+		assert.False(t, m[0].Reversed)
+
+		// False positives
+		m = d().WithDetectReversed(true).ScanProfanity("x spin")
+		assert.True(t, Matches(m).HasProfaneMatch())
+		m = d().WithReversedFalsePositiveWords(en.ReversedFalsePositives).WithDetectReversed(true).
+			ScanProfanity("x spin")
+		assert.False(t, Matches(m).HasProfaneMatch())
+		m = d().WithDetectReversed(false).ScanProfanity("x esra lana parc spin")
+		assert.Nil(t, m)
+
+		// False positive words are reversed
+		m = d().WithDetectReversed(true).WithMatchEmbeddedWords(true).ScanProfanity("x parcs")
+		assert.False(t, Matches(m).HasProfaneMatch())
+
+		m = d().WithDetectReversed(false).ScanProfanity("x kcuf")
+		assert.Nil(t, m)
+	})
+
 	t.Run("Custom confidence calculator tests", func(t *testing.T) {
 		calculator := func(m *Match) bool { return false }
 
@@ -193,6 +317,30 @@ func Test_Scan_One(t *testing.T) {
 	})
 }
 
+func Test_Scan_Offsets(t *testing.T) {
+	d := newDetectorEN
+
+	s := "héllo 😀 fúck 𝒳 $h!t"
+	m := d().ScanAllProfanities(s)
+	assert.Equal(t, 2, len(m))
+
+	assert.Equal(t, "fúck", s[m[0].ByteStart:m[0].ByteEnd])
+	assert.Equal(t, 8, m[0].Start)
+	assert.Equal(t, 9, m[0].UTF16Start)
+	assert.Equal(t, 13, m[0].UTF16End)
+
+	assert.Equal(t, "$h!t", s[m[1].ByteStart:m[1].ByteEnd])
+	assert.Equal(t, 15, m[1].Start)
+	assert.Equal(t, 17, m[1].UTF16Start)
+	assert.Equal(t, 21, m[1].UTF16End)
+
+	// Invalid UTF-8 bytes are counted the same way as they are decoded
+	s = "\xff\xfe fuck"
+	m = d().ScanAllProfanities(s)
+	assert.Equal(t, "fuck", s[m[0].ByteStart:m[0].ByteEnd])
+	assert.Equal(t, 3, m[0].UTF16Start)
+}
+
 func Test_Scan_All(t *testing.T) {
 	// TODO: add tests for this
 }
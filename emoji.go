@@ -0,0 +1,94 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	zeroWidthJoiner = '\u200D'
+)
+
+var (
+	// emojiTable approximates the Extended_Pictographic property plus regional indicators.
+	// Letterlike symbols (such as ℹ and Ⓜ) are excluded as they are commonly used as leet speak.
+	emojiTable = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+			{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+			{Lo: 0x2194, Hi: 0x21AA, Stride: 1},
+			{Lo: 0x231A, Hi: 0x23FF, Stride: 1},
+			{Lo: 0x25AA, Hi: 0x25FE, Stride: 1},
+			{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+			{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+			{Lo: 0x2B05, Hi: 0x2B55, Stride: 1},
+			{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+			{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+			{Lo: 0x3297, Hi: 0x3299, Stride: 2},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x1F000, Hi: 0x1F3FA, Stride: 1},
+			{Lo: 0x1F400, Hi: 0x1FAFF, Stride: 1},
+			{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+		},
+	}
+)
+
+// isEmoji checks if the character is an emoji (pictographic or regional indicator)
+func isEmoji(ch rune) bool {
+	if ch < 0x203C {
+		return false
+	}
+	return unicode.Is(emojiTable, ch)
+}
+
+// isEmojiModifier checks if the character only modifies the previous emoji (skin tones, variation selectors).
+// Such characters don't change meaning of the emoji, so they are ignored when matching.
+func isEmojiModifier(ch rune) bool {
+	return (ch >= 0x1F3FB && ch <= 0x1F3FF) || ch == '\uFE0E' || ch == '\uFE0F'
+}
+
+// removeEmojiModifiers removes all skin tones and variation selectors from the string
+func removeEmojiModifiers(s string) string {
+	if strings.IndexFunc(s, isEmojiModifier) < 0 {
+		return s
+	}
+	return strings.Map(func(ch rune) rune {
+		if isEmojiModifier(ch) {
+			return -1
+		}
+		return ch
+	}, s)
+}
+
+// skipEmojiModifiers returns the position after all emoji modifiers at the position
+func skipEmojiModifiers(input []rune, i int) int {
+	for i < len(input) && isEmojiModifier(input[i]) {
+		i++
+	}
+	return i
+}
+
+// isEmojiEdgeAt checks if the position is at the edge of an emoji cluster. Emoji clusters are
+// treated as separate words, so this works the same way as a space between words.
+func isEmojiEdgeAt(input []rune, i int) bool {
+	if i <= 0 || i >= len(input) {
+		return false
+	}
+	curr := input[i]
+	if curr == zeroWidthJoiner || isEmojiModifier(curr) {
+		return false // inside an emoji cluster
+	}
+	j := i - 1
+	for j >= 0 && isEmojiModifier(input[j]) {
+		j--
+	}
+	if j < 0 {
+		return isEmoji(curr) // only modifiers before the position
+	}
+	prev := input[j]
+	if prev == zeroWidthJoiner {
+		return false // inside an emoji cluster
+	}
+	return isEmoji(prev) || isEmoji(curr)
+}
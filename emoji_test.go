@@ -0,0 +1,76 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_isEmojiEdgeAt(t *testing.T) {
+	input := []rune("a🖕🏽b👨‍👩 c")
+	assert.False(t, isEmojiEdgeAt(input, 0))
+	assert.True(t, isEmojiEdgeAt(input, 1))
+	assert.False(t, isEmojiEdgeAt(input, 2)) // skin tone
+	assert.True(t, isEmojiEdgeAt(input, 3))
+	assert.True(t, isEmojiEdgeAt(input, 4))
+	assert.False(t, isEmojiEdgeAt(input, 5)) // zero width joiner
+	assert.False(t, isEmojiEdgeAt(input, 6))
+	assert.True(t, isEmojiEdgeAt(input, 7))
+	assert.False(t, isEmojiEdgeAt(input, 8))
+
+	// Modifiers at the start of the input
+	assert.False(t, isEmojiEdgeAt([]rune("🏽a"), 1))
+	assert.True(t, isEmojiEdgeAt([]rune("🏽🖕"), 1))
+}
+
+func Test_Scan_Emoji(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().
+			WithProfaneWords(en.EmojiProfanities).
+			WithSuspectWords(en.EmojiSuspects).
+			WithEmojiCharacters(en.EmojiCharacters).
+			WithSanitizeEmoji(true)
+	}
+	var m Matches
+
+	m = d().ScanAllProfanities("you 🖕🏽!")
+	assert.Equal(t, &Match{Word: "🖕", Start: 4, End: 6, WordType: WordTypeProfanity,
+		Text: []rune("🖕🏽"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("lol🖕🖕")
+	assert.Equal(t, 2, len(m.GetProfaneMatches()))
+	assert.Equal(t, 3, m[0].Start)
+	assert.Equal(t, 4, m[1].Start)
+
+	m = d().ScanAllProfanities("🍆💦")
+	assert.Equal(t, &Match{Word: "🍆💦", Start: 0, End: 2, WordType: WordTypeProfanity,
+		Text: []rune("🍆💦"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("nice 🍆 soup")
+	assert.Equal(t, &Match{Word: "🍆", Start: 5, End: 6, WordType: WordTypeSuspect,
+		Text: []rune("🍆"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("f🦆 off")
+	assert.Equal(t, &Match{Word: "fuck", Start: 0, End: 2, WordType: WordTypeProfanity,
+		Text: []rune("f🦆"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("🇫🇺🇨🇰")
+	assert.Equal(t, &Match{Word: "fuck", Start: 0, End: 4, WordType: WordTypeProfanity,
+		Text: []rune("🇫🇺🇨🇰"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("sh❗️t")
+	assert.Equal(t, &Match{Word: "shit", Start: 0, End: 5, WordType: WordTypeProfanity,
+		Text: []rune("sh❗️t"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	// An emoji inside a ZWJ sequence is not a separate word
+	m = d().ScanAllProfanities("🐷‍🔥")
+	assert.Nil(t, m)
+
+	m = d().WithSanitizeEmoji(false).ScanAllProfanities("f🦆 off")
+	assert.Nil(t, m)
+
+	s, _ := d().Censor("f🦆 you 🖕🏿")
+	assert.Equal(t, "** you **", s)
+}
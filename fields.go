@@ -0,0 +1,139 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TextField is a named text of a group of fields scanned together, such as a first name and a last name
+type TextField struct {
+	Name string
+	Text string
+}
+
+// FieldMatch is the part of a match found in a field
+type FieldMatch struct {
+	Field string // name of the field
+	Index int    // index of the field in the group
+
+	// Positions of the part in the text of the field (the same kinds of offsets as of Match)
+	Start      int
+	End        int
+	ByteStart  int
+	ByteEnd    int
+	UTF16Start int
+	UTF16End   int
+	Text       []rune
+}
+
+// FieldsMatch is a match found in a group of fields. Positions of the match refer to the joined text
+// of the fields, Fields are the parts of the match in the fields in order.
+type FieldsMatch struct {
+	*Match
+	Fields []*FieldMatch
+}
+
+type FieldsMatches []*FieldsMatch
+
+// ToMatches returns the matches without the parts in the fields
+func (ms FieldsMatches) ToMatches() (resp Matches) {
+	for _, m := range ms {
+		resp = append(resp, m.Match)
+	}
+	return resp
+}
+
+// fieldGroup is the joined text of a group of fields
+type fieldGroup struct {
+	fields []TextField
+	starts []int // byte positions of the fields in the joined text
+	ends   []int
+	joined string
+}
+
+// newFieldGroup joins the fields with the separator. The joined text is normalized by scanning,
+// offsets of the matches refer to the joined text as it is, so they are mapped back to the fields
+// by their byte positions.
+func newFieldGroup(fields []TextField, separator string) *fieldGroup {
+	group := &fieldGroup{fields: fields}
+	texts := make([]string, len(fields))
+	pos := 0
+	for i, field := range fields {
+		if i > 0 {
+			pos += len(separator)
+		}
+		group.starts = append(group.starts, pos)
+		pos += len(field.Text)
+		group.ends = append(group.ends, pos)
+		texts[i] = field.Text
+	}
+	group.joined = strings.Join(texts, separator)
+	return group
+}
+
+// attribute finds the parts of the match in the fields
+func (g *fieldGroup) attribute(match *Match) *FieldsMatch {
+	fieldsMatch := &FieldsMatch{Match: match}
+	for i, field := range g.fields {
+		start, end := maxInt(match.ByteStart, g.starts[i]), minInt(match.ByteEnd, g.ends[i])
+		if start >= end {
+			continue
+		}
+		part := &FieldMatch{Field: field.Name, Index: i, ByteStart: start - g.starts[i], ByteEnd: end - g.starts[i]}
+		part.Start = utf8.RuneCountInString(field.Text[:part.ByteStart])
+		part.End = part.Start + utf8.RuneCountInString(field.Text[part.ByteStart:part.ByteEnd])
+		offsets := offsetCursor{}
+		_, part.UTF16Start = offsets.moveTo(field.Text, part.Start)
+		_, part.UTF16End = offsets.moveTo(field.Text, part.End)
+		part.Text = []rune(field.Text[part.ByteStart:part.ByteEnd])
+		fieldsMatch.Fields = append(fieldsMatch.Fields, part)
+	}
+	return fieldsMatch
+}
+
+// ScanFields scans the fields as one text joined by FieldSeparator (default: " "), so words split
+// across the fields are detected. For instance, the first name "Fu" and the last name "ck".
+// Matches are attributed back to the fields with the positions in their texts.
+func (d *ProfanityDetector) ScanFields(fields []TextField, options ...DetectorOption) FieldsMatches {
+	scanner := d.newScanner(true, options...)
+	group := newFieldGroup(fields, scanner.settings.FieldSeparator)
+	matches := scanner.scan(group.joined)
+	return group.attributeAll(matches)
+}
+
+// CensorFields scans the fields the same way as ScanFields and censors the profanities in them.
+// The censored fields are returned in the same order.
+func (d *ProfanityDetector) CensorFields(fields []TextField, options ...DetectorOption) (
+	[]TextField, FieldsMatches) {
+	scanner := d.newScanner(true, options...)
+	group := newFieldGroup(fields, scanner.settings.FieldSeparator)
+	matches := scanner.scan(group.joined)
+	result := append([]TextField{}, fields...)
+	if len(matches) == 0 {
+		return result, nil
+	}
+	fieldsMatches := group.attributeAll(matches)
+
+	content := append([]rune(nil), scanner.inputOrig...)
+	censored := make([]bool, len(fields))
+	for _, match := range matches.GetProfaneMatches() {
+		scanner.censorMatch(content, match)
+		for i := range fields {
+			censored[i] = censored[i] || (match.ByteStart < group.ends[i] && match.ByteEnd > group.starts[i])
+		}
+	}
+	for i := range result {
+		if !censored[i] {
+			continue // fields without profanities are returned as they are
+		}
+		result[i].Text = scanner.censoredText(content, group.starts[i], group.ends[i])
+	}
+	return result, fieldsMatches
+}
+
+func (g *fieldGroup) attributeAll(matches Matches) (resp FieldsMatches) {
+	for _, match := range matches {
+		resp = append(resp, g.attribute(match))
+	}
+	return resp
+}
@@ -0,0 +1,78 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScanFields(t *testing.T) {
+	d := newDetectorEN()
+	fields := []TextField{{Name: "first", Text: "Fu"}, {Name: "last", Text: "ck"}, {Name: "bio", Text: "héllo shit"}}
+
+	m := d.ScanFields(fields)
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.Equal(t, 0, m[0].Start)
+	assert.Equal(t, 5, m[0].End)
+	assert.Equal(t, []*FieldMatch{
+		{Field: "first", Index: 0, Start: 0, End: 2, ByteStart: 0, ByteEnd: 2, UTF16Start: 0, UTF16End: 2,
+			Text: []rune("Fu")},
+		{Field: "last", Index: 1, Start: 0, End: 2, ByteStart: 0, ByteEnd: 2, UTF16Start: 0, UTF16End: 2,
+			Text: []rune("ck")},
+	}, m[0].Fields)
+	assert.Equal(t, []*FieldMatch{
+		{Field: "bio", Index: 2, Start: 6, End: 10, ByteStart: 7, ByteEnd: 11, UTF16Start: 6, UTF16End: 10,
+			Text: []rune("shit")},
+	}, m[1].Fields)
+	assert.True(t, m.ToMatches().HasProfaneMatch())
+
+	// Words are not joined across fields when the separator is not sanitized as spaces
+	m = d.ScanFields(fields, WithFieldSeparator("\n"))
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "shit", m[0].Word)
+
+	// Positions in the fields refer to their texts as they are given
+	m = d.ScanFields([]TextField{{Name: "a", Text: "xe"}, {Name: "b", Text: "\u0301 fuck"}}, WithFieldSeparator(""))
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, 3, m[0].Start)
+	assert.Equal(t, []*FieldMatch{
+		{Field: "b", Index: 1, Start: 2, End: 6, ByteStart: 3, ByteEnd: 7, UTF16Start: 2, UTF16End: 6,
+			Text: []rune("fuck")},
+	}, m[0].Fields)
+
+	m = d.ScanFields([]TextField{{Name: "a", Text: "e\u0301"}, {Name: "b", Text: "x fu\u0301ck"}})
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, []*FieldMatch{
+		{Field: "b", Index: 1, Start: 2, End: 7, ByteStart: 2, ByteEnd: 8, UTF16Start: 2, UTF16End: 7,
+			Text: []rune("fu\u0301ck")},
+	}, m[0].Fields)
+
+	m = d.ScanFields(nil)
+	assert.Nil(t, m)
+}
+
+func Test_CensorFields(t *testing.T) {
+	d := newDetectorEN()
+	fields := []TextField{{Name: "first", Text: "Fu"}, {Name: "last", Text: "ck"}, {Name: "bio", Text: "héllo shit"}}
+
+	res, m := d.CensorFields(fields)
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, []TextField{{Name: "first", Text: "**"}, {Name: "last", Text: "**"},
+		{Name: "bio", Text: "héllo ****"}}, res)
+	assert.Equal(t, "Fu", fields[0].Text)
+
+	// Fields without profanities are not normalized
+	res, m = d.CensorFields([]TextField{{Name: "a", Text: "he\u0301llo"}, {Name: "b", Text: "shit"}})
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, []TextField{{Name: "a", Text: "he\u0301llo"}, {Name: "b", Text: "****"}}, res)
+
+	// Characters which are not censored are kept as they are in the censored fields
+	res, m = d.CensorFields([]TextField{{Name: "a", Text: "he\u0301llo fu\u0301ck"}, {Name: "b", Text: "e\u0301"}})
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, []TextField{{Name: "a", Text: "he\u0301llo ****"}, {Name: "b", Text: "e\u0301"}}, res)
+
+	res, m = d.CensorFields([]TextField{{Name: "a", Text: "ok"}})
+	assert.Nil(t, m)
+	assert.Equal(t, []TextField{{Name: "a", Text: "ok"}}, res)
+}
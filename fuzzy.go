@@ -0,0 +1,143 @@
+package profanityout
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultFuzzyMinWordLength = 4
+)
+
+// readToken reads the word at the position and returns its sanitized characters (lowercase,
+// leet speak replaced) and the position after the word. Spaces, special characters mapped to spaces
+// and word edges end the word.
+func (s *scanner) readToken(pos int) (token []rune, end int) {
+	for {
+		ch, next := s.nextCharAt(pos)
+		if ch == 0 || ch == ' ' || (len(token) > 0 && s.isWordEdge(pos)) {
+			return token, pos
+		}
+		ch = unicode.ToLower(ch)
+		if lsCh, exists := s.leetSpeakCharacters[ch]; exists && s.settings.SanitizeLeetSpeak {
+			ch = lsCh
+		} else if s.isWhitespace(ch) {
+			return token, pos
+		}
+		token = append(token, ch)
+		pos = next
+	}
+}
+
+// fuzzySearch finds the closest word to the token within the max edit distance by walking the tree
+// as a Levenshtein automaton (optimal string alignment distance, transpositions are counted as 1 edit)
+type fuzzySearch struct {
+	token       []rune
+	maxDistance int
+	minWordLen  int
+	rows        [][]int // rows of the distance matrix, one for each level of the tree
+
+	found    *wordData
+	distance int
+}
+
+// scanFuzzy scans for a dictionary word which is close to the word at the position
+func (s *scanner) scanFuzzy(pos int, match *Match) {
+	token, end := s.readToken(pos)
+	maxDistance := s.settings.FuzzyMaxEditDistance
+	minWordLen := s.settings.FuzzyMinWordLength
+	if minWordLen <= 0 {
+		minWordLen = defaultFuzzyMinWordLength
+	}
+	if len(token) == 0 || len(token)+maxDistance < minWordLen {
+		return
+	}
+	if s.isKnownToken(token) {
+		return // "duck" is not a misspelling of "dick"
+	}
+
+	search := &fuzzySearch{token: token, maxDistance: maxDistance, minWordLen: minWordLen}
+	firstRow := make([]int, len(token)+1)
+	for i := range firstRow {
+		firstRow[i] = i
+	}
+	search.rows = append(search.rows, firstRow)
+	search.walk(s.profanityTree.root, 0, 0)
+	if search.found == nil {
+		return
+	}
+
+	match.End = end
+	match.WordType = search.found.wordType
+	match.Word = search.found.word
+	match.TailSpace = true
+	match.Text = s.inputOrig[match.Start:match.End]
+	match.EditDistance = search.distance
+}
+
+// isKnownToken checks if the token is a common word or starts with a false positive word.
+// Approximate matching (fuzzy, phonetic) of such tokens is skipped.
+func (s *scanner) isKnownToken(token []rune) bool {
+	if _, exists := s.commonWords[string(token)]; exists {
+		return true
+	}
+	current := s.falsePositiveTree.root
+	for _, ch := range token {
+		if current = current.Next(ch); current == nil {
+			return false
+		}
+		if current.word != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fuzzySearch) walk(current *node, depth int, prevCh rune) {
+	tokenLen := len(f.token)
+	if len(f.rows) <= depth+1 {
+		f.rows = append(f.rows, make([]int, tokenLen+1))
+	}
+	prevRow, row := f.rows[depth], f.rows[depth+1]
+
+	for ch, child := range current.children {
+		if ch == '*' { // wildcard paths are not used for fuzzy matching
+			continue
+		}
+		row[0] = depth + 1
+		rowMin := row[0]
+		for j := 1; j <= tokenLen; j++ {
+			cost := 1
+			if f.token[j-1] == ch {
+				cost = 0
+			}
+			dist := minInt(minInt(prevRow[j]+1, row[j-1]+1), prevRow[j-1]+cost)
+			if depth > 0 && j > 1 && f.token[j-1] == prevCh && f.token[j-2] == ch {
+				dist = minInt(dist, f.rows[depth-1][j-2]+1)
+			}
+			row[j] = dist
+			rowMin = minInt(rowMin, dist)
+		}
+
+		if child.word != nil && row[tokenLen] <= f.maxDistance && f.isBetter(child.word, row[tokenLen]) {
+			f.found, f.distance = child.word, row[tokenLen]
+		}
+		if rowMin <= f.maxDistance && len(child.children) > 0 {
+			f.walk(child, depth+1, ch)
+		}
+	}
+}
+
+func (f *fuzzySearch) isBetter(word *wordData, distance int) bool {
+	if word.wordType >= WordTypeFalsePositive || utf8.RuneCountInString(word.word) < f.minWordLen {
+		return false
+	}
+	if f.found == nil || distance < f.distance {
+		return true
+	}
+	if distance != f.distance {
+		return false
+	}
+	// Makes the result stable as the children of a node are not ordered
+	return word.wordType > f.found.wordType || (word.wordType == f.found.wordType && word.word < f.found.word)
+}
@@ -0,0 +1,69 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_Scan_Fuzzy(t *testing.T) {
+	d := newDetectorEN
+	var m Matches
+
+	m = d().WithFuzzyMaxEditDistance(1).ScanAllProfanities("x fcuk")
+	assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 6, WordType: WordTypeProfanity,
+		Text: []rune("fcuk"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, 1, m[0].EditDistance)
+
+	m = d().ScanAllProfanities("x bi0tch.", WithFuzzyMaxEditDistance(1))
+	assert.Equal(t, &Match{Word: "biatch", Start: 2, End: 8, WordType: WordTypeProfanity,
+		Text: []rune("bi0tch"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, 1, m[0].EditDistance)
+
+	m = d().WithFuzzyMaxEditDistance(1).ScanAllProfanities("x shyyt")
+	assert.Nil(t, m)
+
+	m = d().WithFuzzyMaxEditDistance(2).ScanAllProfanities("x shyyt")
+	assert.Equal(t, "shit", m[0].Word)
+	assert.Equal(t, 2, m[0].EditDistance)
+
+	// Exact matches have edit distance of 0
+	m = d().WithFuzzyMaxEditDistance(1).ScanAllProfanities("x fuck")
+	assert.Equal(t, 0, m[0].EditDistance)
+
+	// Short words are not matched
+	m = d().WithFuzzyMaxEditDistance(1).ScanAllProfanities("x asz")
+	assert.Nil(t, m)
+	m = d().WithFuzzyMaxEditDistance(1).WithFuzzyMinWordLength(3).ScanAllProfanities("x asz")
+	assert.Equal(t, "ass", m[0].Word)
+
+	// Words must be at word boundaries
+	m = d().WithFuzzyMaxEditDistance(1).ScanAllProfanities("x fcukxxx")
+	assert.Nil(t, m)
+
+	// False positives are not matched
+	m = d().WithFuzzyMaxEditDistance(1).ScanAllProfanities("x assassin")
+	assert.False(t, m.HasProfaneMatch())
+
+	// Common words and words starting with false positives are not matched
+	dc := d().WithCommonWords(en.DefaultCommonWords).WithFuzzyMaxEditDistance(1)
+	for _, text := range []string{"duck", "luck", "ship", "shot", "shirt", "pitch", "witch", "count", "dice"} {
+		m = dc.ScanAllProfanities("x " + text)
+		assert.False(t, m.HasProfaneMatch(), text)
+	}
+	m = d().WithFuzzyMaxEditDistance(1).WithFalsePositiveWords([]string{"duc"}).ScanAllProfanities("x duck")
+	assert.False(t, m.HasProfaneMatch())
+	m = dc.ScanAllProfanities("x fcuk")
+	assert.Equal(t, "fuck", m[0].Word)
+
+	// Confidence calculator can weigh the edit distance
+	m = d().WithFuzzyMaxEditDistance(1).
+		WithConfidenceCalculator(func(m *Match) bool { return m.EditDistance == 0 }).
+		ScanAllProfanities("x fcuk")
+	assert.Nil(t, m)
+
+	m = d().WithFuzzyMaxEditDistance(0).ScanAllProfanities("x fcuk")
+	assert.Nil(t, m)
+}
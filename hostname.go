@@ -0,0 +1,79 @@
+package profanityout
+
+import (
+	"strings"
+)
+
+// isHostnameSeparator checks if the character separates labels of hostnames and segments of paths
+func isHostnameSeparator(ch rune) bool {
+	return ch == '.' || ch == '-' || ch == '_' || ch == '/'
+}
+
+// decodeHostname builds the text to scan from a hostname or a path. Labels are separated by spaces
+// and punycode labels ("xn--") are decoded to Unicode. For every character of the result,
+// starts and ends are its position in the input. All characters of a decoded label are mapped to
+// the whole label in the input.
+func decodeHostname(input []rune) (text []rune, starts, ends []int) {
+	text = make([]rune, 0, len(input))
+	starts = make([]int, 0, len(input))
+	ends = make([]int, 0, len(input))
+	appendChar := func(ch rune, start, end int) {
+		text = append(text, ch)
+		starts = append(starts, start)
+		ends = append(ends, end)
+	}
+
+	for i := 0; i < len(input); {
+		// Labels are only split by dots and slashes here as punycode labels contain hyphens
+		labelEnd := i
+		for labelEnd < len(input) && input[labelEnd] != '.' && input[labelEnd] != '/' {
+			labelEnd++
+		}
+		label := string(input[i:labelEnd])
+		if len(label) > len(idnaACEPrefix) && strings.EqualFold(label[:len(idnaACEPrefix)], idnaACEPrefix) {
+			if decoded, err := decodePunycode(label[len(idnaACEPrefix):]); err == nil {
+				for _, ch := range normalizeAsNFC(decoded) {
+					if isHostnameSeparator(ch) {
+						ch = ' '
+					}
+					appendChar(ch, i, labelEnd)
+				}
+				i = labelEnd
+			}
+		}
+		for ; i < labelEnd; i++ {
+			ch := input[i]
+			if isHostnameSeparator(ch) {
+				ch = ' '
+			}
+			appendChar(ch, i, i+1)
+		}
+		if i < len(input) {
+			appendChar(' ', i, i+1)
+			i++
+		}
+	}
+	return text, starts, ends
+}
+
+// ScanHostname scans for all profanities in a hostname, a URL path or a slug (e.g. "fuck-you.example.com",
+// "/blog/fuck_this"). Labels and segments are split by dots, hyphens, underscores and slashes, and
+// internationalized labels ("xn--...") are decoded to Unicode before scanning.
+//
+// Positions of matches refer to the input. A match in a decoded label covers the whole label
+// as there is no position mapping between the characters of the label and its encoded form.
+func (d *ProfanityDetector) ScanHostname(hostname string, options ...DetectorOption) Matches {
+	normalized, inputOffsets := normalizeAsNFCWithOffsets(hostname)
+	input := []rune(normalized)
+	text, starts, ends := decodeHostname(input)
+
+	matches := d.newScanner(true, options...).scan(string(text))
+	// Offsets are calculated in the input the same way as by the scanner
+	offsets := &scanner{inputText: hostname, inputOffsets: inputOffsets}
+	for _, match := range matches {
+		match.Start, match.End = starts[match.Start], ends[match.End-1]
+		match.Text = input[match.Start:match.End]
+		offsets.updateMatchOffsets(match)
+	}
+	return matches
+}
@@ -0,0 +1,61 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodePunycode(t *testing.T) {
+	s, err := decodePunycode("mnchen-3ya")
+	assert.Nil(t, err)
+	assert.Equal(t, "münchen", s)
+
+	s, err = decodePunycode("ihqwcrb4cv8a8dqg056pqjye")
+	assert.Nil(t, err)
+	assert.Equal(t, "他们为什么不说中文", s)
+
+	s, err = decodePunycode("-> $1.00 <--")
+	assert.Nil(t, err)
+	assert.Equal(t, "-> $1.00 <-", s)
+
+	_, err = decodePunycode("zz")
+	assert.ErrorIs(t, err, errInvalidPunycode)
+	_, err = decodePunycode("ab-c!")
+	assert.ErrorIs(t, err, errInvalidPunycode)
+}
+
+func Test_ScanHostname(t *testing.T) {
+	d := newDetectorEN()
+	var m Matches
+
+	m = d.ScanHostname("fuck-you.example.com")
+	assert.Equal(t, &Match{Word: "fuck", Start: 0, End: 4, WordType: WordTypeProfanity,
+		Text: []rune("fuck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d.ScanHostname("/blog/sh1t_post/123")
+	assert.Equal(t, []rune("sh1t"), m[0].Text)
+	assert.Equal(t, 6, m[0].Start)
+
+	// Punycode labels are decoded, the match covers the whole label
+	m = d.ScanHostname("www.XN--fck-hoa.example.com")
+	assert.Equal(t, &Match{Word: "fuck", Start: 4, End: 15, WordType: WordTypeProfanity,
+		Text: []rune("XN--fck-hoa"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, 4, m[0].ByteStart)
+	assert.Equal(t, 15, m[0].ByteEnd)
+
+	// Confusable characters in decoded labels ("fuсk" with Cyrillic "с")
+	m = d.ScanHostname("xn--fuk-5ed.net")
+	assert.Equal(t, "fuck", m[0].Word)
+
+	// Invalid punycode labels are scanned as they are
+	m = d.ScanHostname("xn--zzzzzzz.com")
+	assert.Nil(t, m)
+
+	// Offsets refer to the input when it's not normalized
+	m = d.ScanHostname("/cafe\u0301/fu\u0301ck")
+	assert.Equal(t, "fu\u0301ck", "/cafe\u0301/fu\u0301ck"[m[0].ByteStart:m[0].ByteEnd])
+
+	m = d.ScanHostname("assassin.example.com/classic")
+	assert.False(t, m.HasProfaneMatch())
+}
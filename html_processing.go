@@ -1,151 +1,228 @@
 package profanityout
 
-import "strconv"
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// htmlEntityMaxLen is the length of the longest named entity "&CounterClockwiseContourIntegral;"
+	htmlEntityMaxLen = 33
+)
 
 var (
-	htmlEntityMap = map[string]rune{
-		"quot":   '"',
-		"apos":   '\'',
-		"amp":    '&',
-		"lt":     '<',
-		"gt":     '>',
-		"nbsp":   ' ',
-		"iexcl":  '¡',
-		"cent":   '¢',
-		"pound":  '£',
-		"curren": '¤',
-		"yen":    '¥',
-		"brvbar": '¦',
-		"sect":   '§',
-		"uml":    '¨',
-		"copy":   '©',
-		"ordf":   'ª',
-		"laquo":  '«',
-		"not":    '¬',
-		"shy":    '­',
-		"reg":    '®',
-		"macr":   '¯',
-		"deg":    '°',
-		"plusmn": '±',
-		"sup2":   '²',
-		"sup3":   '³',
-		"acute":  '´',
-		"micro":  'µ',
-		"para":   '¶',
-		"middot": '·',
-		"cedil":  '¸',
-		"sup1":   '¹',
-		"ordm":   'º',
-		"raquo":  '»',
-		"frac14": '¼',
-		"frac12": '½',
-		"frac34": '¾',
-		"iquest": '¿',
-		"times":  '×',
-		"divide": '÷',
-		"Agrave": 'À',
-		"Aacute": 'Á',
-		"Acirc":  'Â',
-		"Atilde": 'Ã',
-		"Auml":   'Ä',
-		"Aring":  'Å',
-		"AElig":  'Æ',
-		"Ccedil": 'Ç',
-		"Egrave": 'È',
-		"Eacute": 'É',
-		"Ecirc":  'Ê',
-		"Euml":   'Ë',
-		"Igrave": 'Ì',
-		"Iacute": 'Í',
-		"Icirc":  'Î',
-		"Iuml":   'Ï',
-		"ETH":    'Ð',
-		"Ntilde": 'Ñ',
-		"Ograve": 'Ò',
-		"Oacute": 'Ó',
-		"Ocirc":  'Ô',
-		"Otilde": 'Õ',
-		"Ouml":   'Ö',
-		"Oslash": 'Ø',
-		"Ugrave": 'Ù',
-		"Uacute": 'Ú',
-		"Ucirc":  'Û',
-		"Uuml":   'Ü',
-		"Yacute": 'Ý',
-		"THORN":  'Þ',
-		"szlig":  'ß',
-		"agrave": 'à',
-		"aacute": 'á',
-		"acirc":  'â',
-		"atilde": 'ã',
-		"auml":   'ä',
-		"aring":  'å',
-		"aelig":  'æ',
-		"ccedil": 'ç',
-		"egrave": 'è',
-		"eacute": 'é',
-		"ecirc":  'ê',
-		"euml":   'ë',
-		"igrave": 'ì',
-		"iacute": 'í',
-		"icirc":  'î',
-		"iuml":   'ï',
-		"eth":    'ð',
-		"ntilde": 'ñ',
-		"ograve": 'ò',
-		"oacute": 'ó',
-		"ocirc":  'ô',
-		"otilde": 'õ',
-		"ouml":   'ö',
-		"oslash": 'ø',
-		"ugrave": 'ù',
-		"uacute": 'ú',
-		"ucirc":  'û',
-		"uuml":   'ü',
-		"yacute": 'ý',
-		"thorn":  'þ',
-		"yuml":   'ÿ',
+	// htmlBlockTags are tags ending words
+	htmlBlockTags = map[string]struct{}{
+		"address": {}, "article": {}, "aside": {}, "blockquote": {}, "body": {}, "br": {}, "button": {},
+		"caption": {}, "dd": {}, "details": {}, "dialog": {}, "div": {}, "dl": {}, "dt": {}, "fieldset": {},
+		"figcaption": {}, "figure": {}, "footer": {}, "form": {}, "h1": {}, "h2": {}, "h3": {}, "h4": {},
+		"h5": {}, "h6": {}, "head": {}, "header": {}, "hr": {}, "html": {}, "legend": {}, "li": {},
+		"main": {}, "menu": {}, "nav": {}, "ol": {}, "option": {}, "p": {}, "pre": {}, "section": {},
+		"select": {}, "summary": {}, "table": {}, "tbody": {}, "td": {}, "textarea": {}, "tfoot": {},
+		"th": {}, "thead": {}, "title": {}, "tr": {}, "ul": {},
+	}
+	// htmlRawTextTags are tags whose content is not shown as text (scripts, styles)
+	htmlRawTextTags = map[string]struct{}{
+		"script": {}, "style": {},
+	}
+	// htmlTextAttributes are attributes whose values are text shown to users
+	htmlTextAttributes = map[string]struct{}{
+		"alt": {}, "title": {}, "placeholder": {},
 	}
 )
 
-func skipHTMLTag(content []rune, i int) (next int) {
+// htmlTag is a tag found in HTML input
+type htmlTag struct {
+	name      string // lowercase name
+	closing   bool
+	start     int
+	end       int
+	textAttrs [][2]int // ranges of the values of text attributes
+}
+
+// parseHTMLTagAt parses a tag at the position, attribute values can be quoted and contain '>'.
+// ok is false when there is no valid tag, for instance "<3" or "<a <b>".
+//
+//nolint:gocognit,gocyclo
+func parseHTMLTagAt(content []rune, i int) (tag htmlTag, ok bool) {
 	length := len(content)
+	if i >= length || content[i] != '<' {
+		return tag, false
+	}
 	j := i + 1
+	if j < length && content[j] == '/' {
+		tag.closing = true
+		j++
+	}
+	nameStart := j
+	for j < length && (unicode.IsLetter(content[j]) || (j > nameStart && (unicode.IsDigit(content[j]) ||
+		content[j] == '-' || content[j] == ':'))) {
+		j++
+	}
+	if j == nameStart && !(tag.closing && j < length && content[j] == '>') {
+		return tag, false
+	}
+	tag.name = strings.ToLower(string(content[nameStart:j]))
+
 	for j < length {
-		// TODO: checks that no '<' appears again in between a tag
-		if content[j] == '>' {
-			return j + 1
+		ch := content[j]
+		switch {
+		case ch == '>':
+			tag.start, tag.end = i, j+1
+			return tag, true
+		case ch == '<':
+			return tag, false
+		case unicode.IsSpace(ch) || ch == '/':
+			j++
+			continue
+		}
+
+		attrStart := j
+		for j < length && !unicode.IsSpace(content[j]) && !strings.ContainsRune("=></", content[j]) {
+			j++
+		}
+		attrName := strings.ToLower(string(content[attrStart:j]))
+		for j < length && unicode.IsSpace(content[j]) {
+			j++
+		}
+		if j >= length || content[j] != '=' {
+			continue
 		}
 		j++
+		for j < length && unicode.IsSpace(content[j]) {
+			j++
+		}
+		if j >= length {
+			break
+		}
+		var valueStart, valueEnd int
+		if quote := content[j]; quote == '"' || quote == '\'' {
+			valueStart = j + 1
+			valueEnd = valueStart
+			for valueEnd < length && content[valueEnd] != quote {
+				valueEnd++
+			}
+			if valueEnd >= length {
+				return tag, false
+			}
+			j = valueEnd + 1
+		} else {
+			valueStart = j
+			for j < length && !unicode.IsSpace(content[j]) && content[j] != '>' {
+				if content[j] == '<' {
+					return tag, false
+				}
+				j++
+			}
+			valueEnd = j
+		}
+		if _, exists := htmlTextAttributes[attrName]; exists && valueStart < valueEnd {
+			tag.textAttrs = append(tag.textAttrs, [2]int{valueStart, valueEnd})
+		}
+	}
+	return tag, false
+}
+
+// skipHTMLSpecialTag returns the position after a comment, a CDATA section, a doctype or
+// a processing instruction at the position, or the position itself when there is none.
+// Unterminated comments and CDATA sections end at the end of the content.
+func skipHTMLSpecialTag(content []rune, i int) (next int) {
+	for _, delims := range [][2]string{{"<!--", "-->"}, {"<![CDATA[", "]]>"}, {"<!", ">"}, {"<?", ">"}} {
+		if !hasPrefixFold(content[i:], delims[0]) {
+			continue
+		}
+		end := indexRunes(content[i+len(delims[0]):], delims[1])
+		if end < 0 {
+			return len(content)
+		}
+		return i + len(delims[0]) + end + len(delims[1])
 	}
 	return i
 }
 
+// parseHTML classifies the characters of HTML content. Inline tags, comments, CDATA sections, scripts
+// and styles are hidden, block tags and "<br>" end words. Values of text attributes (alt, title, placeholder)
+// are scanned as separate words.
+func parseHTML(content []rune) []markupClass {
+	markup := make([]markupClass, len(content))
+	for i := 0; i < len(content); {
+		if content[i] != '<' {
+			i++
+			continue
+		}
+		if next := skipHTMLSpecialTag(content, i); next != i {
+			markMarkup(markup, i, next, markupHidden)
+			i = next
+			continue
+		}
+		tag, ok := parseHTMLTagAt(content, i)
+		if !ok {
+			i++
+			continue
+		}
+
+		class := markupHidden
+		if _, exists := htmlBlockTags[tag.name]; exists {
+			class = markupBreak
+		}
+		markMarkup(markup, tag.start, tag.end, class)
+		for _, attr := range tag.textAttrs {
+			// The characters around the value separate it from the text around the tag
+			markMarkup(markup, attr[0]-1, attr[0], markupBreak)
+			markMarkup(markup, attr[0], attr[1], markupText)
+			markMarkup(markup, attr[1], attr[1]+1, markupBreak)
+		}
+		i = tag.end
+
+		if _, exists := htmlRawTextTags[tag.name]; exists && !tag.closing {
+			end := i
+			for end < len(content) {
+				if closing, ok := parseHTMLTagAt(content, end); ok && closing.closing && closing.name == tag.name {
+					break
+				}
+				end++
+			}
+			markMarkup(markup, i, end, markupHidden)
+			i = end
+		}
+	}
+	return markup
+}
+
+// decodeHTMLEntityAt decodes a character reference at the position as HTML5 does. Named entities,
+// legacy entities without the trailing ';' ("&gt") and decimal and hexadecimal references ("&#102;",
+// "&#x66;") are supported. Spaces such as "&nbsp;" are decoded as ' '.
 func decodeHTMLEntityAt(content []rune, i int) (rune, int) {
-	length := len(content)
+	if i+1 >= len(content) || content[i] != '&' {
+		return 0, i
+	}
 	j := i + 1
-	found := false
-	for j < length {
-		if content[j] == ';' {
-			found = true
+	for j < len(content) && j-i < htmlEntityMaxLen {
+		ch := content[j]
+		j++
+		if ch == ';' {
+			break
+		}
+		if ch > unicode.MaxASCII || !(unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '#') {
+			j--
 			break
 		}
-		j++
 	}
-	if !found || j-i > 10 { //nolint:mnd
+	entity := content[i:j]
+	decoded := []rune(html.UnescapeString(string(entity)))
+	if string(decoded) == string(entity) {
 		return 0, i
 	}
-	name := string(content[i+1 : j])
-	j++
-	if name[0] == '#' {
-		code, err := strconv.ParseInt(name[1:], 10, 32)
-		if err != nil {
-			return 0, i
-		}
-		return rune(code), j
+
+	// The characters after the entity are kept by unescaping, at least one character is decoded
+	kept := 0
+	for kept < len(decoded)-1 && decoded[len(decoded)-1-kept] == entity[len(entity)-1-kept] {
+		kept++
 	}
-	if ch, exists := htmlEntityMap[name]; exists {
-		return ch, j
+	ch := decoded[0]
+	if unicode.IsSpace(ch) {
+		ch = ' '
 	}
-	return 0, i
+	return ch, j - kept
 }
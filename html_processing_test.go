@@ -6,11 +6,35 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_skipHTMLTag(t *testing.T) {
-	assert.Equal(t, 0, skipHTMLTag([]rune(""), 0))
-	assert.Equal(t, 5, skipHTMLTag([]rune("<tag>"), 0))
-	assert.Equal(t, 5, skipHTMLTag([]rune("<tag> x"), 0))
-	assert.Equal(t, 0, skipHTMLTag([]rune("<tag"), 0))
+func Test_parseHTMLTagAt(t *testing.T) {
+	// Ends of the valid tags, 0 when there is no valid tag
+	for text, end := range map[string]int{
+		"": 0, "<tag>": 5, "<tag> x": 5, "<tag": 0, "<3 x>": 0, "<a <b>": 0, "</a>": 4,
+		`<a href="x>y">z`: 14, `<a href="x>y`: 0,
+	} {
+		tag, ok := parseHTMLTagAt([]rune(text), 0)
+		assert.Equal(t, end > 0, ok, text)
+		if ok {
+			assert.Equal(t, end, tag.end, text)
+		}
+	}
+
+	tag, _ := parseHTMLTagAt([]rune("</A>"), 0)
+	assert.Equal(t, htmlTag{name: "a", closing: true, start: 0, end: 4}, tag)
+}
+
+func Test_ParseHTML(t *testing.T) {
+	classes := func(text string) string {
+		return markupClasses(parseHTML([]rune(text)))
+	}
+
+	assert.Equal(t, "TTHHHTTHHHH", classes("fu<b>ck</b>"))
+	assert.Equal(t, "TTBBBBTT", classes("fu<br>ck"))
+	assert.Equal(t, "HHHHHHHHHHTT", classes("<!-- x -->ck"))
+	assert.Equal(t, "HHHHHHHHHHHHHHHHTT", classes("<style>x</style>yz"))
+	assert.Equal(t, "THHHHHHHHHBTTBH", classes("x<img alt='ab'>"))
+	assert.Equal(t, "HHHHHHHHBTBTHHHH", classes("<a title=x>y</a>"))
+	assert.Equal(t, "TTTT", classes("<3 x"))
 }
 
 func Test_decodeHTMLEntityAt(t *testing.T) {
@@ -23,7 +47,14 @@ func Test_decodeHTMLEntityAt(t *testing.T) {
 	ch, i = decodeHTMLEntityAt([]rune("x&gt;"), 1)
 	assert.True(t, ch == '>' && i == 5)
 
+	// Legacy entities don't require ';'
 	ch, i = decodeHTMLEntityAt([]rune("x&gt"), 1)
+	assert.True(t, ch == '>' && i == 4)
+	ch, i = decodeHTMLEntityAt([]rune("x&amp;a"), 1)
+	assert.True(t, ch == '&' && i == 6)
+	ch, i = decodeHTMLEntityAt([]rune("x&ampa"), 1)
+	assert.True(t, ch == '&' && i == 5)
+	ch, i = decodeHTMLEntityAt([]rune("x&hyphen"), 1)
 	assert.True(t, ch == 0 && i == 1)
 
 	ch, i = decodeHTMLEntityAt([]rune("x&xxxxxx;"), 1)
@@ -34,4 +65,92 @@ func Test_decodeHTMLEntityAt(t *testing.T) {
 
 	ch, i = decodeHTMLEntityAt([]rune("x&#xxxx;"), 1)
 	assert.True(t, ch == 0 && i == 1)
+
+	ch, i = decodeHTMLEntityAt([]rune("x&#x66;"), 1)
+	assert.True(t, ch == 'f' && i == 7)
+	ch, i = decodeHTMLEntityAt([]rune("x&#X66uck"), 1)
+	assert.True(t, ch == 'f' && i == 6)
+
+	// HTML5 entities
+	ch, i = decodeHTMLEntityAt([]rune("&hyphen;&ast;&period;"), 0)
+	assert.True(t, ch == '‐' && i == 8)
+	ch, i = decodeHTMLEntityAt([]rune("&hyphen;&ast;&period;"), 8)
+	assert.True(t, ch == '*' && i == 13)
+	ch, i = decodeHTMLEntityAt([]rune("&hyphen;&ast;&period;"), 13)
+	assert.True(t, ch == '.' && i == 21)
+	ch, i = decodeHTMLEntityAt([]rune("&semi;"), 0)
+	assert.True(t, ch == ';' && i == 6)
+	ch, i = decodeHTMLEntityAt([]rune("&CounterClockwiseContourIntegral;x"), 0)
+	assert.True(t, ch == '∳' && i == 33)
+	ch, i = decodeHTMLEntityAt([]rune("&nbsp;"), 0)
+	assert.True(t, ch == ' ' && i == 6)
+}
+
+func Test_Scan_HTML(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithProcessInputAsHTML(true)
+	}
+	var m Matches
+
+	m = d().ScanAllProfanities("x fu<b>ck</b> y")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, 2, m[0].Start)
+	assert.Equal(t, 9, m[0].End)
+
+	// Block tags end words, even when spaces are sanitized
+	m = d().ScanAllProfanities("fu<br>ck fu</p><p>ck")
+	assert.Equal(t, 0, len(m))
+	m = d().ScanAllProfanities("fu ck")
+	assert.Equal(t, 1, len(m))
+
+	// Scripts, styles, comments and CDATA sections are skipped
+	m = d().ScanAllProfanities("<script>fuck()</script><style>.shit{}</style><!-- cunt --><![CDATA[fuck]]> ok")
+	assert.Equal(t, 0, len(m))
+	m = d().ScanAllProfanities("fu<!-- x -->ck")
+	assert.Equal(t, 1, len(m))
+
+	// Text attributes are scanned with their own offsets, other attributes are not
+	m = d().ScanAllProfanities(`fu<img alt="shit" src="/cunt.png">ck`)
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "shit", m[0].Word)
+	assert.Equal(t, 12, m[0].Start)
+	assert.Equal(t, []rune("shit"), m[0].Text)
+}
+
+func Test_Scan_HTMLEntities(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithProcessInputAsHTML(true)
+	}
+	var m Matches
+
+	m = d().ScanAllProfanities("x &#x66;uck y")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, 2, m[0].Start)
+	assert.Equal(t, 11, m[0].End)
+	assert.Equal(t, []rune("&#x66;uck"), m[0].Text)
+
+	for _, text := range []string{"&#102uck", "f&uacute;ck", "fu&ast;k", "fu&hyphen;ck you", "fu&mdash;ck",
+		"sh&#X69;t&period;"} {
+		m = d().ScanAllProfanities(text)
+		assert.True(t, m.HasProfaneMatch(), text)
+	}
+
+	// Dashes are sanitized the same way however they are written
+	for _, text := range []string{"fu—ck", "fu–ck", "fu&ndash;ck"} {
+		m = d().ScanAllProfanities(text)
+		assert.True(t, m.HasProfaneMatch(), text)
+	}
+	m = newDetectorEN().ScanAllProfanities("fu—ck")
+	assert.True(t, m.HasProfaneMatch())
+}
+
+func Test_Censor_HTML(t *testing.T) {
+	d := newDetectorEN().WithProcessInputAsHTML(true).WithCensorCharacter('#')
+
+	s, _ := d.Censor(`<p title="shit">fu<b>ck</b></p><script>fuck()</script>`)
+	assert.Equal(t, `<p title="####">##<b>##</b></p><script>fuck()</script>`, s)
+
+	// Entities are replaced by one censor character, tags are kept
+	s, _ = d.Censor("x &lt;ock f<b>uc</b>k &amp; <a title='sh&#105;t'>fu&nbsp;ck</a>")
+	assert.Equal(t, "x #### #<b>##</b># &amp; <a title='####'>##&nbsp;##</a>", s)
 }
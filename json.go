@@ -0,0 +1,302 @@
+package profanityout
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrJSONDuplicateKey = errors.New("profanityout: censoring creates duplicate keys in a JSON object")
+)
+
+// JSONMatch is a match found in a string of a JSON document
+type JSONMatch struct {
+	*Match
+	// Pointer is the JSON Pointer (RFC 6901) of the value, or of the member when the key is matched.
+	// Positions of the match refer to the string.
+	Pointer string
+	// Key is true when the match is found in the key of an object member
+	Key bool
+}
+
+type JSONMatches []*JSONMatch
+
+// ToMatches returns the matches without the paths
+func (ms JSONMatches) ToMatches() (resp Matches) {
+	for _, m := range ms {
+		resp = append(resp, m.Match)
+	}
+	return resp
+}
+
+// jsonFrame is an object or an array being read
+type jsonFrame struct {
+	isArray   bool
+	index     int    // index of the next item of an array
+	key       string // key of the current member of an object
+	expectKey bool
+	// keys written to the output of an object, true for the keys which are censored
+	writtenKeys map[string]bool
+}
+
+// jsonWalker reads a JSON document token by token, scans the strings and optionally writes the document
+// with the strings censored
+type jsonWalker struct {
+	scanner   *scanner
+	censor    bool
+	decoder   *json.Decoder
+	out       *bytes.Buffer
+	stack     []jsonFrame
+	matches   JSONMatches
+	needComma bool
+}
+
+// ScanJSON scans all string values of the JSON document read from the reader.
+// Object keys are scanned when JSONScanKeys is on, and the values scanned can be filtered with
+// JSONIncludePaths and JSONExcludePaths.
+//
+// For instance, `{"comments": [{"text": "fuck"}]}` has a match at "/comments/0/text".
+func (d *ProfanityDetector) ScanJSON(r io.Reader, options ...DetectorOption) (JSONMatches, error) {
+	walker := d.newJSONWalker(r, false, options...)
+	if err := walker.walk(); err != nil {
+		return nil, err
+	}
+	return walker.matches, nil
+}
+
+// CensorJSON censors all profanities in the string values of the JSON document read from the reader
+// and writes the document to the writer. The output is compact valid JSON, order of object members is kept.
+// When object keys are scanned, ErrJSONDuplicateKey is returned if censoring makes a key the same as
+// another key of the object ("fuck" and "shit" both censored as "****"), nothing is written then.
+func (d *ProfanityDetector) CensorJSON(r io.Reader, w io.Writer, options ...DetectorOption) (JSONMatches, error) {
+	walker := d.newJSONWalker(r, true, options...)
+	if err := walker.walk(); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(walker.out.Bytes()); err != nil {
+		return nil, err
+	}
+	return walker.matches, nil
+}
+
+func (d *ProfanityDetector) newJSONWalker(r io.Reader, censor bool, options ...DetectorOption) *jsonWalker {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return &jsonWalker{
+		scanner: d.newScanner(true, options...),
+		censor:  censor,
+		decoder: decoder,
+		out:     &bytes.Buffer{},
+	}
+}
+
+//nolint:gocognit,gocyclo
+func (w *jsonWalker) walk() error {
+	for {
+		token, err := w.decoder.Token()
+		if errors.Is(err, io.EOF) {
+			if len(w.stack) > 0 {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var frame *jsonFrame
+		if len(w.stack) > 0 {
+			frame = &w.stack[len(w.stack)-1]
+		}
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			w.stack = w.stack[:len(w.stack)-1]
+			w.writeRaw(string(delim))
+			w.endValue()
+			continue
+		}
+
+		if frame != nil && frame.expectKey {
+			frame.key = token.(string) //nolint:forcetypeassert // keys are always strings
+			frame.expectKey = false
+			key := frame.key
+			if w.scanner.settings.JSONScanKeys && w.isPathScanned() {
+				key = w.scanString(key, true)
+			}
+			if err := w.checkKey(frame, key); err != nil {
+				return err
+			}
+			w.writeComma()
+			w.writeString(key)
+			w.writeRaw(":")
+			w.needComma = false
+			continue
+		}
+
+		w.writeComma()
+		switch value := token.(type) {
+		case json.Delim:
+			w.writeRaw(string(value))
+			w.stack = append(w.stack, jsonFrame{isArray: value == '[', expectKey: value == '{'})
+			w.needComma = false
+			continue
+		case string:
+			if w.isPathScanned() {
+				value = w.scanString(value, false)
+			}
+			w.writeString(value)
+		case json.Number:
+			w.writeRaw(value.String())
+		case bool:
+			w.writeRaw(strconv.FormatBool(value))
+		case nil:
+			w.writeRaw("null")
+		}
+		w.endValue()
+	}
+}
+
+// checkKey checks that the key written doesn't duplicate another key of the object because of censoring,
+// the keys duplicated in the document are kept as they are
+func (w *jsonWalker) checkKey(frame *jsonFrame, key string) error {
+	if !w.censor {
+		return nil
+	}
+	censored := key != frame.key
+	if frame.writtenKeys == nil {
+		frame.writtenKeys = make(map[string]bool)
+	}
+	if otherCensored, exists := frame.writtenKeys[key]; exists && (censored || otherCensored) {
+		return fmt.Errorf("%w: %q at %q", ErrJSONDuplicateKey, key, w.pointer())
+	}
+	frame.writtenKeys[key] = frame.writtenKeys[key] || censored
+	return nil
+}
+
+// endValue moves to the next item of the current array or object
+func (w *jsonWalker) endValue() {
+	w.needComma = true
+	if len(w.stack) == 0 {
+		w.writeRaw("\n")
+		w.needComma = false
+		return
+	}
+	frame := &w.stack[len(w.stack)-1]
+	if frame.isArray {
+		frame.index++
+	} else {
+		frame.expectKey = true
+	}
+}
+
+// path returns the JSON Pointer segments of the current value
+func (w *jsonWalker) path() []string {
+	segments := make([]string, 0, len(w.stack))
+	for _, frame := range w.stack {
+		if frame.isArray {
+			segments = append(segments, strconv.Itoa(frame.index))
+		} else {
+			segments = append(segments, frame.key)
+		}
+	}
+	return segments
+}
+
+func (w *jsonWalker) pointer() string {
+	var sb strings.Builder
+	for _, segment := range w.path() {
+		sb.WriteByte('/')
+		sb.WriteString(jsonPointerEscaper.Replace(segment))
+	}
+	return sb.String()
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// isPathScanned checks the current path against the include and exclude paths
+func (w *jsonWalker) isPathScanned() bool {
+	settings := w.scanner.settings
+	if len(settings.JSONIncludePaths) == 0 && len(settings.JSONExcludePaths) == 0 {
+		return true
+	}
+	path := w.path()
+	for _, pattern := range settings.JSONExcludePaths {
+		if matchJSONPointer(pattern, path) {
+			return false
+		}
+	}
+	if len(settings.JSONIncludePaths) == 0 {
+		return true
+	}
+	for _, pattern := range settings.JSONIncludePaths {
+		if matchJSONPointer(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchJSONPointer checks if the path is the value of the pointer or a descendant of it.
+// A "*" segment of the pointer matches any key or index.
+func matchJSONPointer(pointer string, path []string) bool {
+	if pointer == "" {
+		return true // the whole document
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return false
+	}
+	segments := strings.Split(pointer[1:], "/")
+	if len(segments) > len(path) {
+		return false
+	}
+	for i, segment := range segments {
+		if segment != "*" && jsonPointerUnescaper.Replace(segment) != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// scanString scans the string and returns it censored when censoring
+func (w *jsonWalker) scanString(value string, isKey bool) string {
+	matches := w.scanner.scan(value)
+	if len(matches) == 0 {
+		return value
+	}
+	pointer := w.pointer()
+	for _, match := range matches {
+		w.matches = append(w.matches, &JSONMatch{Match: match, Pointer: pointer, Key: isKey})
+	}
+	if !w.censor {
+		return value
+	}
+	return w.scanner.censorInput(matches)
+}
+
+func (w *jsonWalker) writeComma() {
+	if w.needComma {
+		w.writeRaw(",")
+	}
+}
+
+func (w *jsonWalker) writeRaw(s string) {
+	if w.censor {
+		w.out.WriteString(s)
+	}
+}
+
+func (w *jsonWalker) writeString(s string) {
+	if !w.censor {
+		return
+	}
+	encoder := json.NewEncoder(w.out)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	w.out.Truncate(w.out.Len() - 1) // the newline written by the encoder
+}
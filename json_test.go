@@ -0,0 +1,84 @@
+package profanityout
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchJSONPointer(t *testing.T) {
+	assert.True(t, matchJSONPointer("", []string{"a"}))
+	assert.True(t, matchJSONPointer("/a", []string{"a"}))
+	assert.True(t, matchJSONPointer("/a", []string{"a", "b"}))
+	assert.False(t, matchJSONPointer("/a/b", []string{"a"}))
+	assert.False(t, matchJSONPointer("/a", []string{"ab"}))
+	assert.True(t, matchJSONPointer("/*/b", []string{"0", "b", "c"}))
+	assert.True(t, matchJSONPointer("/a~1b~0c", []string{"a/b~c"}))
+	assert.False(t, matchJSONPointer("a", []string{"a"}))
+}
+
+func Test_ScanJSON(t *testing.T) {
+	d := newDetectorEN()
+	doc := `{"user": {"name": "bob", "bio": "x fuck"}, "tags": ["ok", "shit", 1.5, true, null],
+		"a/b~c": "cunt", "fuck you": {}}`
+
+	m, err := d.ScanJSON(strings.NewReader(doc))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(m))
+	assert.Equal(t, "/user/bio", m[0].Pointer)
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.Equal(t, 2, m[0].Start)
+	assert.Equal(t, "/tags/1", m[1].Pointer)
+	assert.Equal(t, "/a~1b~0c", m[2].Pointer)
+	assert.True(t, m.ToMatches().HasProfaneMatch())
+
+	m, err = d.ScanJSON(strings.NewReader(doc), WithJSONScanKeys(true))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(m))
+	assert.Equal(t, "/fuck you", m[3].Pointer)
+	assert.True(t, m[3].Key)
+
+	m, err = d.ScanJSON(strings.NewReader(doc), WithJSONIncludePaths("/user", "/tags/*"),
+		WithJSONExcludePaths("/tags/1"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "/user/bio", m[0].Pointer)
+
+	_, err = d.ScanJSON(strings.NewReader(`{"a": "fuck"`))
+	assert.NotNil(t, err)
+	_, err = d.ScanJSON(strings.NewReader(`{"a" 1}`))
+	assert.NotNil(t, err)
+}
+
+func Test_CensorJSON(t *testing.T) {
+	d := newDetectorEN().WithCensorCharacter('#')
+
+	var out bytes.Buffer
+	m, err := d.CensorJSON(strings.NewReader(`{"b": ["fuck <you>", 1.50, null], "a": {"fuck": "shit"}}`), &out)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, `{"b":["#### <you>",1.50,null],"a":{"fuck":"####"}}`+"\n", out.String())
+
+	out.Reset()
+	_, err = d.CensorJSON(strings.NewReader(`{"fuck": "x"} ["shit"]`), &out, WithJSONScanKeys(true))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"####":"x"}`+"\n"+`["####"]`+"\n", out.String())
+
+	// Censoring keys must not create duplicate keys
+	for _, text := range []string{`{"a": {"fuck": 1, "shit": 2}}`, `{"fuck": 1, "####": 2}`, `{"####": 1, "shit": 2}`} {
+		out.Reset()
+		_, err = d.CensorJSON(strings.NewReader(text), &out, WithJSONScanKeys(true))
+		assert.ErrorIs(t, err, ErrJSONDuplicateKey, text)
+		assert.Equal(t, "", out.String())
+	}
+	_, err = d.CensorJSON(strings.NewReader(`{"a": {"fuck": 1, "shit": 2}}`), &out, WithJSONScanKeys(true))
+	assert.EqualError(t, err, `profanityout: censoring creates duplicate keys in a JSON object: "####" at "/a/shit"`)
+
+	// Keys duplicated in the document are kept
+	out.Reset()
+	_, err = d.CensorJSON(strings.NewReader(`{"a": 1, "a": 2, "fuck": {"fuck": 3}}`), &out, WithJSONScanKeys(true))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1,"a":2,"####":{"####":3}}`+"\n", out.String())
+}
@@ -0,0 +1,250 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode"
+)
+
+// parseMarkdown classifies the characters of Markdown text. Emphasis markers and link brackets are hidden,
+// so "f**u**ck" is scanned as "fuck". Block markers, link destinations, autolinks and code are scanned
+// as spaces. Alt text of images and text of links are scanned.
+func parseMarkdown(input []rune) []markupClass {
+	markup := make([]markupClass, len(input))
+	inFence := false
+	for lineStart := 0; lineStart < len(input); {
+		lineEnd := lineStart
+		for lineEnd < len(input) && input[lineEnd] != '\n' {
+			lineEnd++
+		}
+
+		i := lineStart
+		for i < lineEnd && i-lineStart < 4 && input[i] == ' ' { //nolint:mnd
+			i++
+		}
+		if isMarkdownCodeFence(input[i:lineEnd]) {
+			inFence = !inFence
+			markMarkup(markup, lineStart, lineEnd, markupSpace)
+		} else if inFence {
+			markMarkup(markup, lineStart, lineEnd, markupSpace)
+		} else {
+			i = skipMarkdownBlockMarkers(input, markup, i, lineEnd)
+			parseMarkdownInline(input, markup, i, lineEnd)
+		}
+		if lineEnd < len(input) {
+			markup[lineEnd] = markupSpace // line breaks separate words
+		}
+		lineStart = lineEnd + 1
+	}
+	return markup
+}
+
+func markMarkup(markup []markupClass, start, end int, class markupClass) {
+	for i := start; i < end; i++ {
+		markup[i] = class
+	}
+}
+
+func isMarkdownCodeFence(line []rune) bool {
+	return len(line) >= 3 && (string(line[:3]) == "```" || string(line[:3]) == "~~~")
+}
+
+// skipMarkdownBlockMarkers marks headings, block quotes and list markers at the line start
+func skipMarkdownBlockMarkers(input []rune, markup []markupClass, i, end int) int {
+	for i < end {
+		j := i
+		switch ch := input[i]; {
+		case ch == '#':
+			for j < end && input[j] == '#' {
+				j++
+			}
+		case ch == '>':
+			j++
+		case ch == '-' || ch == '*' || ch == '+':
+			j++
+		case ch >= '0' && ch <= '9':
+			for j < end && input[j] >= '0' && input[j] <= '9' {
+				j++
+			}
+			if j == end || (input[j] != '.' && input[j] != ')') {
+				return i
+			}
+			j++
+		default:
+			return i
+		}
+		// Markers must be followed by a space except for block quotes
+		if j < end && input[j] != ' ' && input[i] != '>' {
+			return i
+		}
+		for j < end && input[j] == ' ' {
+			j++
+		}
+		markMarkup(markup, i, j, markupSpace)
+		i = j
+	}
+	return i
+}
+
+type markdownDelimiter struct {
+	pos, length int
+	ch          rune
+}
+
+// parseMarkdownInline marks the inline markup in input[start:end]
+//
+//nolint:gocognit,gocyclo
+func parseMarkdownInline(input []rune, markup []markupClass, start, end int) {
+	var openers []markdownDelimiter
+	for i := start; i < end; {
+		if markup[i] != markupText { // already marked as a part of a link
+			i++
+			continue
+		}
+		ch := input[i]
+		switch {
+		case ch == '\\' && i+1 < end && (unicode.IsPunct(input[i+1]) || unicode.IsSymbol(input[i+1])):
+			markup[i] = markupHidden // escaped character is text
+			i += 2
+
+		case ch == '`':
+			length := runLength(input, i, end)
+			if closing := findBacktickRun(input, i+length, end, length); closing >= 0 {
+				markMarkup(markup, i, closing+length, markupSpace)
+				i = closing + length
+			} else {
+				i += length
+			}
+
+		case ch == '<':
+			if closing := findAutolinkEnd(input, i, end); closing >= 0 {
+				markMarkup(markup, i, closing, markupSpace)
+				i = closing
+			} else {
+				i++
+			}
+
+		case ch == '[' || (ch == '!' && i+1 < end && input[i+1] == '['):
+			bracket := i
+			if ch == '!' {
+				bracket++
+			}
+			if destEnd, closing := findMarkdownLinkEnd(input, bracket, end); destEnd >= 0 {
+				markMarkup(markup, i, bracket+1, markupHidden)
+				markMarkup(markup, closing, destEnd, markupSpace)
+				i = bracket + 1
+			} else {
+				i++
+			}
+
+		case ch == '*' || ch == '_' || ch == '~':
+			length := runLength(input, i, end)
+			if ch == '~' && length < 2 { //nolint:mnd
+				i++
+				continue
+			}
+			// Closes the last opener of the same kind, the openers in between are not closed anymore
+			matched := false
+			for j := len(openers) - 1; j >= 0; j-- {
+				if openers[j].ch == ch && openers[j].length == length {
+					markMarkup(markup, openers[j].pos, openers[j].pos+length, markupHidden)
+					markMarkup(markup, i, i+length, markupHidden)
+					openers = openers[:j]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				openers = append(openers, markdownDelimiter{pos: i, length: length, ch: ch})
+			}
+			i += length
+
+		default:
+			i++
+		}
+	}
+}
+
+func runLength(input []rune, i, end int) int {
+	j := i
+	for j < end && input[j] == input[i] {
+		j++
+	}
+	return j - i
+}
+
+// findBacktickRun finds a run of backticks of exactly the length
+func findBacktickRun(input []rune, i, end, length int) int {
+	for i < end {
+		if input[i] != '`' {
+			i++
+			continue
+		}
+		runLen := runLength(input, i, end)
+		if runLen == length {
+			return i
+		}
+		i += runLen
+	}
+	return -1
+}
+
+// findAutolinkEnd finds the end of an autolink such as "<https://example.com>" or "<me@example.com>"
+func findAutolinkEnd(input []rune, i, end int) int {
+	for j := i + 1; j < end; j++ {
+		switch input[j] {
+		case ' ', '<':
+			return -1
+		case '>':
+			content := string(input[i+1 : j])
+			if strings.Contains(content, "://") || (strings.Contains(content, "@") && !strings.HasPrefix(content, "@")) {
+				return j + 1
+			}
+			return -1
+		}
+	}
+	return -1
+}
+
+// findMarkdownLinkEnd finds the closing bracket of a link starting at the opening bracket and
+// the end of its destination "(url)" or reference "[ref]"
+func findMarkdownLinkEnd(input []rune, bracket, end int) (destEnd, closing int) {
+	depth := 0
+	closing = -1
+	for j := bracket; j < end; j++ {
+		if input[j] == '\\' {
+			j++
+			continue
+		}
+		if input[j] == '[' {
+			depth++
+		} else if input[j] == ']' {
+			if depth--; depth == 0 {
+				closing = j
+				break
+			}
+		}
+	}
+	if closing < 0 || closing+1 >= end {
+		return -1, -1
+	}
+	var openCh, closeCh rune
+	switch input[closing+1] {
+	case '(':
+		openCh, closeCh = '(', ')'
+	case '[':
+		openCh, closeCh = '[', ']'
+	default:
+		return -1, -1
+	}
+	depth = 0
+	for j := closing + 1; j < end; j++ {
+		if input[j] == openCh {
+			depth++
+		} else if input[j] == closeCh {
+			if depth--; depth == 0 {
+				return j + 1, closing
+			}
+		}
+	}
+	return -1, -1
+}
@@ -0,0 +1,59 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseMarkdown(t *testing.T) {
+	classes := func(text string) string {
+		return markupClasses(parseMarkdown([]rune(text)))
+	}
+
+	assert.Equal(t, "THHTHHTT", classes("f**u**ck"))
+	assert.Equal(t, "THTHTTT", classes("f*o*o x"))
+	assert.Equal(t, "TTTT", classes("f*ck"))
+	assert.Equal(t, "HTTTT______TT", classes("[link](url) x"))
+	assert.Equal(t, "HHTTT__________", classes("![alt](url.png)"))
+	assert.Equal(t, "TT______TT", classes("a `code` b"))
+	assert.Equal(t, "__T__________", classes("# a\n```\nb\n```"))
+	assert.Equal(t, "THTT", classes("a\\*b"))
+}
+
+func Test_Scan_Markdown(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithProcessInputAsMarkdown(true)
+	}
+	var m Matches
+
+	m = d().ScanAllProfanities("x f**u**ck, sh_i_t")
+	assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 10, WordType: WordTypeProfanity,
+		Text: []rune("f**u**ck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, []rune("sh_i_t"), m[1].Text)
+
+	// Matches don't include the markup around them
+	m = d().ScanAllProfanities("**fuck** this")
+	assert.Equal(t, []rune("fuck"), m[0].Text)
+	assert.Equal(t, 2, m[0].Start)
+
+	// Link text and image alt text are scanned, destinations and code are not
+	m = d().ScanAllProfanities("[shit](https://example.com/fuck) ![cunt](x.png) `fuck` <https://fuck.com>")
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, []rune("shit"), m[0].Text)
+	assert.Equal(t, []rune("cunt"), m[1].Text)
+
+	m = d().ScanAllProfanities("```\nfuck\n```\n# shit\n> cunt")
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, 15, m[0].Start)
+
+	m = d().ScanAllProfanities("x `fuck`", WithProcessInputAsMarkdown(false))
+	assert.True(t, m.HasProfaneMatch())
+}
+
+func Test_Censor_Markdown(t *testing.T) {
+	d := newDetectorEN().WithProcessInputAsMarkdown(true).WithCensorCharacter('#')
+
+	s, _ := d.Censor("x f**u**ck, [sh_i_t](https://example.com) `fuck`")
+	assert.Equal(t, "x #**#**##, [##_#_#](https://example.com) `fuck`", s)
+}
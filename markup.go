@@ -0,0 +1,166 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// markupClass classifies the characters of the input in markup processing modes
+type markupClass uint8
+
+const (
+	// markupText is text content which is scanned and censored
+	markupText markupClass = iota
+	// markupHidden is markup syntax inside text ("**" in "f**u**ck"), it's skipped when scanning
+	markupHidden
+	// markupSpace is markup syntax separating words or content excluded from scanning (code, quotes),
+	// it's scanned as a space
+	markupSpace
+	// markupBreak is markup syntax ending words such as block tags ("fu<br>ck"), it's scanned as a space
+	// which is never removed by space sanitization
+	markupBreak
+)
+
+// processMarkup classifies the characters of the input according to the markup processing modes
+func (s *scanner) processMarkup() {
+	s.markup = nil
+	if s.settings.ProcessInputAsHTML {
+		s.mergeMarkup(parseHTML(s.input))
+	}
+	if s.settings.ProcessInputAsMarkdown {
+		s.mergeMarkup(parseMarkdown(s.input))
+	}
+	if s.settings.ProcessInputAsBBCode {
+		s.mergeMarkup(parseBBCode(s.input, s.settings.BBCodeExcludeQuotes))
+	}
+}
+
+// mergeMarkup merges the classes of characters, the most restrictive class wins
+func (s *scanner) mergeMarkup(markup []markupClass) {
+	if s.markup == nil {
+		s.markup = markup
+		return
+	}
+	for i, class := range markup {
+		if class > s.markup[i] {
+			s.markup[i] = class
+		}
+	}
+}
+
+// skipMarkup returns the position of the next character which is not of the class
+func (s *scanner) skipMarkup(i int, class markupClass) int {
+	for i < len(s.markup) && s.markup[i] == class {
+		i++
+	}
+	return i
+}
+
+func (s *scanner) isMarkupAt(i int) bool {
+	return s.markup != nil && i < len(s.markup) && s.markup[i] != markupText
+}
+
+// isMarkupBreakAt checks if the next character scanned from the position is a break, hidden markup is skipped
+func (s *scanner) isMarkupBreakAt(i int) bool {
+	i = s.skipMarkup(i, markupHidden)
+	return s.isMarkupAt(i) && s.markup[i] == markupBreak
+}
+
+// censorDeleted marks characters deleted from the censored content, it's never a valid character
+const censorDeleted rune = -1
+
+// censorMatch replaces the characters of the match in the content by the censor character.
+// Spaces and markup are kept. An HTML entity is
+// replaced by one censor character, the rest of it is marked as deleted (see compactCensored),
+// so are combining marks ignored when accents are sanitized.
+func (s *scanner) censorMatch(content []rune, match *Match) {
+	for i := match.Start; i < match.End; i++ {
+		if content[i] == ' ' || s.isMarkupAt(i) {
+			continue
+		}
+		if s.settings.SanitizeAccents && isStrippedMark(s.inputOrig[i]) {
+			content[i] = censorDeleted // the mark of a censored character
+			continue
+		}
+		if content[i] == '&' && s.settings.ProcessInputAsHTML {
+			if ch, next := decodeHTMLEntityAt(s.inputOrig, i); next != i {
+				if ch == ' ' {
+					i = next - 1
+					continue
+				}
+				for j := i + 1; j < next; j++ {
+					content[j] = censorDeleted
+				}
+				content[i] = s.settings.CensorCharacter
+				i = next - 1
+				continue
+			}
+		}
+		content[i] = s.settings.CensorCharacter
+	}
+}
+
+// censorInput censors the profane matches in the input and returns the result
+func (s *scanner) censorInput(matches Matches) string {
+	// The input is censored in place when it's converted to the characters as it is
+	inPlace := s.inputOffsets == nil && utf8.ValidString(s.inputText)
+	content := s.inputOrig
+	if !inPlace {
+		content = append([]rune(nil), s.inputOrig...)
+	}
+	for _, match := range matches.GetProfaneMatches() {
+		s.censorMatch(content, match)
+	}
+	if inPlace {
+		return string(compactCensored(content))
+	}
+	return s.censoredText(content, 0, len(s.inputText))
+}
+
+// censoredText returns the part input[from:to] (byte offsets) censored by the content, which is
+// a censored copy of inputOrig. Characters which are not censored are copied from the input,
+// so the input is kept as it is apart from the matches when it's not normalized.
+func (s *scanner) censoredText(content []rune, from, to int) string {
+	var result strings.Builder
+	s.offsets = offsetCursor{}
+	for i := 0; i < len(content); {
+		j := i + 1
+		for s.inputOffsets != nil && j < len(content) && s.inputOffsets[j] == s.inputOffsets[i] {
+			j++ // characters normalized from the same segment of the input
+		}
+		start, end := s.inputOffsetAt(i, false).byteOffset, s.inputOffsetAt(j, false).byteOffset
+		if start >= to {
+			break
+		}
+		switch {
+		case isCensored(content[i:j], s.inputOrig[i:j]):
+			if start >= from {
+				result.WriteString(string(compactCensored(content[i:j])))
+			}
+		case end > from:
+			result.WriteString(s.inputText[maxInt(start, from):minInt(end, to)])
+		}
+		i = j
+	}
+	return result.String()
+}
+
+func isCensored(content, orig []rune) bool {
+	for i := range content {
+		if content[i] != orig[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// compactCensored removes the characters marked as deleted by censorMatch
+func compactCensored(content []rune) []rune {
+	result := content[:0]
+	for _, ch := range content {
+		if ch != censorDeleted {
+			result = append(result, ch)
+		}
+	}
+	return result
+}
@@ -0,0 +1,11 @@
+package profanityout
+
+// markupClasses encodes the classes of the characters as a string for comparison in tests:
+// 'T' for text, 'H' for hidden markup, '_' for markup scanned as a space and 'B' for breaks
+func markupClasses(markup []markupClass) string {
+	buf := make([]rune, len(markup))
+	for i, class := range markup {
+		buf[i] = []rune("TH_B")[class]
+	}
+	return string(buf)
+}
@@ -1,15 +1,46 @@
 package profanityout
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidWordType = errors.New("profanityout: invalid word type")
+)
+
 type Match struct {
 	Word      string
 	WordType  WordType
-	Start     int
-	End       int
+	Start     int // rune index of the match start
+	End       int // rune index of the match end (exclusive)
 	HeadSpace bool
 	TailSpace bool
 	Text      []rune
 	Settings  *DetectorSettings
 
+	// Offsets of the match in the UTF-8 bytes and the UTF-16 code units of the input.
+	// These can be used to slice the input string directly or to highlight text in
+	// environments like JavaScript which count characters in UTF-16 code units.
+	// NOTE: the input is normalized as NFC before scanning, Start, End and Text refer to the normalized
+	// text (which is the same as the input in most cases), the offsets always refer to the input.
+	ByteStart  int
+	ByteEnd    int
+	UTF16Start int
+	UTF16End   int
+
+	// Reversed is true when the match is found in reversed text ("kcuf")
+	Reversed bool
+	// EditDistance is the number of edits between the sanitized text and the word in fuzzy matching mode
+	EditDistance int
+	// Phonetic is true when the match is found by phonetic matching ("phuk")
+	Phonetic bool
+	// Phrase is true when the match is a multi-word phrase ("son of a bitch"), Word is the phrase then
+	Phrase bool
+	// Cooccurrence is true when the match is combined by a co-occurrence rule, Word is the label of the rule then
+	Cooccurrence bool
+
 	// private fields
 	foundRealCharMatch bool
 }
@@ -27,6 +58,28 @@ const (
 	WordTypeFalsePositive WordType = 30
 )
 
+// UnmarshalJSON parses a word type from its name ("suspect", "profanity") or its number
+func (wt *WordType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var value int8
+		if err = json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidWordType, data)
+		}
+		*wt = WordType(value)
+		return nil
+	}
+	switch name {
+	case "suspect":
+		*wt = WordTypeSuspect
+	case "profanity":
+		*wt = WordTypeProfanity
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidWordType, name)
+	}
+	return nil
+}
+
 type Matches []*Match
 
 func (ms Matches) HasProfaneMatch() bool {
@@ -0,0 +1,204 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultPhoneticMinKeyLength = 3
+)
+
+// phoneticKey calculates a phonetic key of an English word. This is a simplified variant of Metaphone:
+// consonants which sound the same share a code and a run of vowels is reduced to a code of its first
+// vowel (A for "a", I for "e/i/y", O for "o/u"). Characters other than a-z are ignored.
+//
+// For instance, "fuck", "phuk", "fukk" have the same key "FOK", "shit" and "sheeit" have the key "XIT".
+//
+//nolint:gocognit,gocyclo,funlen
+func phoneticKey(word []rune) string {
+	letters := make([]rune, 0, len(word))
+	for _, ch := range word {
+		ch = unicode.ToLower(ch)
+		if ch >= 'a' && ch <= 'z' {
+			letters = append(letters, ch)
+		}
+	}
+	length := len(letters)
+	at := func(i int) rune {
+		if i < 0 || i >= length {
+			return 0
+		}
+		return letters[i]
+	}
+	isVowel := func(ch rune) bool {
+		return strings.ContainsRune("aeiouy", ch)
+	}
+
+	var key strings.Builder
+	var last rune
+	emit := func(codes string) {
+		for _, code := range codes {
+			if code != last {
+				key.WriteRune(code)
+				last = code
+			}
+		}
+	}
+
+	i := 0
+	switch at(0) {
+	case 'k', 'g', 'p':
+		if at(1) == 'n' {
+			i = 1 // silent: knob, gnat, pneumonia
+		}
+	case 'w':
+		if at(1) == 'r' || at(1) == 'h' {
+			i = 1 // silent: wrong, who
+		}
+	}
+
+	for ; i < length; i++ {
+		ch, next := letters[i], at(i+1)
+		if isVowel(ch) {
+			if isVowel(at(i - 1)) {
+				continue // only the first vowel of a run is used
+			}
+			if ch == 'e' && i == length-1 && i > 2 {
+				continue // silent ending e: pube, rape
+			}
+			switch ch {
+			case 'a':
+				emit("A")
+			case 'o', 'u':
+				emit("O")
+			default:
+				emit("I")
+			}
+			continue
+		}
+
+		switch ch {
+		case 'c':
+			switch {
+			case next == 'h':
+				emit("X")
+				i++
+			case next == 'k':
+				emit("K")
+				i++
+			case next == 'e' || next == 'i' || next == 'y':
+				emit("S")
+			default:
+				emit("K")
+			}
+		case 'd':
+			if next == 'g' && (at(i+2) == 'e' || at(i+2) == 'i' || at(i+2) == 'y') {
+				emit("J")
+				i++
+			} else {
+				emit("D")
+			}
+		case 'g':
+			switch {
+			case next == 'h':
+				if i == 0 {
+					emit("K")
+				}
+				i++ // silent gh: sheesh, though
+			case next == 'e' || next == 'i' || next == 'y':
+				emit("J")
+			default:
+				emit("K")
+			}
+		case 'h':
+			if i == 0 && isVowel(next) {
+				emit("H")
+			}
+		case 'p':
+			if next == 'h' {
+				emit("F")
+				i++
+			} else {
+				emit("P")
+			}
+		case 'q':
+			emit("K")
+		case 's':
+			if next == 'h' {
+				emit("X")
+				i++
+			} else {
+				emit("S")
+			}
+		case 't':
+			switch {
+			case next == 'h':
+				emit("0")
+				i++
+			case next == 'c' && at(i+2) == 'h':
+				// silent: the "ch" is handled next
+			default:
+				emit("T")
+			}
+		case 'v':
+			emit("F")
+		case 'w':
+			if isVowel(next) {
+				emit("W")
+			}
+		case 'x':
+			emit("KS")
+		case 'z':
+			emit("S")
+		default:
+			emit(string(unicode.ToUpper(ch)))
+		}
+	}
+	return key.String()
+}
+
+// phoneticIndex maps phonetic keys to dictionary words
+type phoneticIndex map[string]*wordData
+
+func (index phoneticIndex) Add(word string, wordType WordType) {
+	word = strings.Trim(normalizeAsNFC(word), "*")
+	if strings.Contains(word, "*") {
+		return // words with wildcards inside can't be represented by a phonetic key
+	}
+	key := phoneticKey([]rune(removeAccents(word)))
+	if key == "" {
+		return
+	}
+	if current, exists := index[key]; exists && current.wordType >= wordType {
+		return
+	}
+	index[key] = &wordData{word: word, wordType: wordType}
+}
+
+// scanPhonetic scans for a dictionary word which sounds the same as the word at the position
+func (s *scanner) scanPhonetic(pos int, match *Match) {
+	token, end := s.readToken(pos)
+	minKeyLen := s.settings.PhoneticMinKeyLength
+	if minKeyLen <= 0 {
+		minKeyLen = defaultPhoneticMinKeyLength
+	}
+	key := phoneticKey(token)
+	if len(key) < minKeyLen || s.isKnownToken(token) {
+		return // "cook" sounds like "cock", but is a normal word
+	}
+	word, exists := s.phoneticIndex[key]
+	if !exists {
+		return
+	}
+
+	match.End = end
+	match.WordType = word.wordType
+	if s.settings.PhoneticMatchType != 0 {
+		match.WordType = s.settings.PhoneticMatchType
+	}
+	match.Word = word.word
+	match.TailSpace = true
+	match.Text = s.inputOrig[match.Start:match.End]
+	match.Phonetic = true
+}
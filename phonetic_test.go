@@ -0,0 +1,76 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_PhoneticKey(t *testing.T) {
+	for _, word := range []string{"fuck", "phuk", "fukk", "fook", "phuck"} {
+		assert.Equal(t, "FOK", phoneticKey([]rune(word)), word)
+	}
+	for _, word := range []string{"shit", "sheeit", "SHYT"} {
+		assert.Equal(t, "XIT", phoneticKey([]rune(word)), word)
+	}
+	for _, word := range []string{"bitch", "biatch", "biyatch"} {
+		assert.Equal(t, "BIX", phoneticKey([]rune(word)), word)
+	}
+	assert.Equal(t, "NOB", phoneticKey([]rune("knob")))
+	assert.Equal(t, "RAP", phoneticKey([]rune("rape")))
+	assert.Equal(t, "", phoneticKey([]rune("123")))
+}
+
+func Test_Scan_Phonetic(t *testing.T) {
+	d := newDetectorEN
+	var m Matches
+
+	// Phonetic matches are suspects by default
+	m = d().WithDetectPhonetic(true).ScanAllProfanities("x phuk")
+	assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 6, WordType: WordTypeSuspect,
+		Text: []rune("phuk"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.True(t, m[0].Phonetic)
+	assert.False(t, m.HasProfaneMatch())
+
+	m = d().ScanAllProfanities("x sheeit, biyatch", WithDetectPhonetic(true))
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, "shit", m[0].Word)
+	assert.Equal(t, []rune("sheeit"), m[0].Text)
+	assert.Equal(t, []rune("biyatch"), m[1].Text)
+
+	// Match type can be configured, the type of the dictionary word is used when it's 0
+	m = d().WithDetectPhonetic(true).WithPhoneticMatchType(0).ScanAllProfanities("x phuk")
+	assert.Equal(t, WordTypeProfanity, m[0].WordType)
+	assert.True(t, m.HasProfaneMatch())
+
+	// Exact matches are not phonetic
+	m = d().WithDetectPhonetic(true).ScanAllProfanities("x fuck")
+	assert.False(t, m[0].Phonetic)
+
+	// Short keys are not matched
+	m = d().WithDetectPhonetic(true).ScanAllProfanities("x fuk")
+	assert.True(t, m[0].Phonetic)
+	m = d().WithDetectPhonetic(true).WithPhoneticMinKeyLength(4).ScanAllProfanities("x phuk")
+	assert.Nil(t, m)
+
+	// Words must be at word boundaries
+	m = d().WithDetectPhonetic(true).ScanAllProfanities("x phukxyz")
+	assert.Nil(t, m)
+
+	// False positives and common words are not matched
+	dc := d().WithCommonWords(en.DefaultCommonWords).WithDetectPhonetic(true).WithPhoneticMatchType(0)
+	for _, word := range []string{"fog", "cook", "coke", "dig", "deck", "count", "gum", "beach",
+		"come", "fake", "sheet", "six"} {
+		assert.Nil(t, dc.ScanAllProfanities("x "+word), word)
+	}
+	m = d().WithFalsePositiveWords([]string{"phu"}).WithDetectPhonetic(true).ScanAllProfanities("x phuk")
+	assert.Equal(t, 1, len(m))
+	assert.True(t, m[0].IsFalsePositive())
+	m = dc.ScanAllProfanities("x phuk")
+	assert.True(t, m.HasProfaneMatch())
+
+	m = d().WithDetectPhonetic(false).ScanAllProfanities("x phuk")
+	assert.Nil(t, m)
+}
@@ -0,0 +1,206 @@
+package profanityout
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// phraseWord is a word of a phrase with the gap allowed before it
+type phraseWord struct {
+	alternatives []string // the words accepted, a trailing "*" accepts any word with the prefix
+	maxGap       int      // the max number of other words between this word and the previous one
+}
+
+// phrase is a multi-word entry such as "son of a bitch" or "go kill|hurt ~1 yourself"
+type phrase struct {
+	text     string
+	words    []phraseWord
+	wordType WordType
+}
+
+// parsePhrase parses a phrase made of words separated by spaces. A word can have alternatives separated
+// by '|' ("kill|hurt") and a trailing '*' matching any word with the prefix ("fuck*"). A gap rule "~N"
+// between two words allows up to N other words between them.
+//
+// For instance, "suck ~2 dick" matches "suck my dick" and "suck my big dick".
+func parsePhrase(text string, wordType WordType) *phrase {
+	text = strings.Join(strings.Fields(normalizeAsNFC(text)), " ")
+	p := &phrase{text: text, wordType: wordType}
+	gap := 0
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "~") {
+			if n, err := strconv.Atoi(field[1:]); err == nil && n >= 0 {
+				gap += n
+				continue
+			}
+		}
+		var alternatives []string
+		for _, alternative := range strings.Split(strings.ToLower(field), "|") {
+			if alternative = removeAccents(alternative); alternative != "" && alternative != "*" {
+				alternatives = append(alternatives, alternative)
+			}
+		}
+		if len(alternatives) == 0 {
+			continue
+		}
+		p.words = append(p.words, phraseWord{alternatives: alternatives, maxGap: gap})
+		gap = 0
+	}
+	if len(p.words) < 2 { //nolint:mnd
+		return nil // single words belong to the dictionaries
+	}
+	return p
+}
+
+// matches checks if the token is one of the words, sanitized is false when only the letters
+// of the token are matched
+func (w *phraseWord) matches(token *phraseToken) (matched, sanitized bool) {
+	for _, text := range []string{token.sanitized, token.letters} {
+		for _, alternative := range w.alternatives {
+			if prefix, ok := strings.CutSuffix(alternative, "*"); ok {
+				if strings.HasPrefix(text, prefix) {
+					return true, text == token.sanitized
+				}
+			} else if text == alternative {
+				return true, text == token.sanitized
+			}
+		}
+	}
+	return false, false
+}
+
+// phraseToken is a word of the input. Sanitized is the word with leet speak replaced, letters is
+// the word with only its letters ("yourself" of "yourself!"), both are lowercase.
+type phraseToken struct {
+	start, end int
+	lettersEnd int // the position after the last letter
+	sanitized  string
+	letters    string
+}
+
+// readPhraseTokens splits the input into words
+func (s *scanner) readPhraseTokens() (tokens []*phraseToken) {
+	for pos := s.startPos; ; {
+		ch, next := s.nextCharAt(pos)
+		if ch == 0 {
+			break
+		}
+		if !s.shouldStartScanning(ch) {
+			pos = next
+			continue
+		}
+		sanitized, end := s.readToken(pos)
+		if end == pos {
+			pos = next
+			continue
+		}
+		token := &phraseToken{start: pos, end: end, lettersEnd: end, sanitized: string(sanitized)}
+		var letters []rune
+		for i := pos; i < end; {
+			ch, next = s.nextCharAt(i)
+			if ch = unicode.ToLower(ch); unicode.IsLetter(ch) {
+				letters = append(letters, ch)
+				token.lettersEnd = next
+			}
+			i = next
+		}
+		token.letters = string(letters)
+		tokens = append(tokens, token)
+		pos = end
+	}
+	return tokens
+}
+
+// matchPhrase matches the phrase with the tokens starting at the index, the end position of the phrase
+// is returned, or -1 when the phrase is not matched
+func matchPhrase(p *phrase, tokens []*phraseToken, index, wordIndex int) int {
+	matched, sanitized := p.words[wordIndex].matches(tokens[index])
+	if !matched {
+		return -1
+	}
+	if wordIndex == len(p.words)-1 {
+		if !sanitized {
+			return tokens[index].lettersEnd // trailing punctuation is not a part of the phrase ("bitch!")
+		}
+		return tokens[index].end
+	}
+	next := &p.words[wordIndex+1]
+	for i := index + 1; i < len(tokens) && i <= index+1+next.maxGap; i++ {
+		if end := matchPhrase(p, tokens, i, wordIndex+1); end >= 0 {
+			return end
+		}
+	}
+	return -1
+}
+
+// findPhrases finds the phrases in the tokens, a match is skipped when it overlaps a previous match
+func findPhrases(phrases []*phrase, tokens []*phraseToken) (found []*Match) {
+	for i := range tokens {
+		if len(found) > 0 && tokens[i].start < found[len(found)-1].End {
+			continue
+		}
+		for _, p := range phrases {
+			if end := matchPhrase(p, tokens, i, 0); end >= 0 {
+				found = append(found, &Match{Word: p.text, WordType: p.wordType, Start: tokens[i].start,
+					End: end, HeadSpace: true, TailSpace: true, Phrase: true})
+				break
+			}
+		}
+	}
+	return found
+}
+
+// scanPhrases scans for the phrases and merges them into the matches found. Matches of single words
+// inside a phrase are replaced by the phrase match unless they are more severe than it ("bitch" inside
+// a suspect phrase). Phrases overlapping false positive phrases are ignored. When scanning for the first
+// profanity, the matches after the first profane match are dropped.
+func (s *scanner) scanPhrases(matches Matches) Matches {
+	if len(s.phrases) == 0 {
+		return matches
+	}
+	tokens := s.readPhraseTokens()
+	phraseMatches := findPhrases(s.phrases, tokens)
+	if len(phraseMatches) == 0 {
+		return matches
+	}
+	falsePositives := findPhrases(s.falsePositivePhrases, tokens)
+
+	for _, match := range phraseMatches {
+		if isOverlapped(match, falsePositives) {
+			continue
+		}
+		match.Text = s.inputOrig[match.Start:match.End]
+		match.Settings = s.settings
+		if !s.settings.ConfidenceCalculator(match) {
+			continue
+		}
+		// Matches of words inside the phrase are replaced
+		kept := matches[:0]
+		for _, m := range matches {
+			if m.Start < match.Start || m.End > match.End || m.WordType > match.WordType {
+				kept = append(kept, m)
+			}
+		}
+		matches = append(kept, match)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Start < matches[j].Start
+	})
+	for _, match := range matches {
+		if match.Phrase {
+			s.updateMatchOffsets(match)
+		}
+	}
+	return matches
+}
+
+func isOverlapped(match *Match, matches []*Match) bool {
+	for _, m := range matches {
+		if m.Start < match.End && match.Start < m.End {
+			return true
+		}
+	}
+	return false
+}
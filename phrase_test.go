@@ -0,0 +1,85 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parsePhrase(t *testing.T) {
+	p := parsePhrase("  Go ~1 kill|HURT   yourself* ", WordTypeProfanity)
+	assert.Equal(t, "Go ~1 kill|HURT yourself*", p.text)
+	assert.Equal(t, []phraseWord{
+		{alternatives: []string{"go"}},
+		{alternatives: []string{"kill", "hurt"}, maxGap: 1},
+		{alternatives: []string{"yourself*"}},
+	}, p.words)
+
+	assert.Nil(t, parsePhrase("fuck", WordTypeProfanity))
+	assert.Nil(t, parsePhrase("fuck ~2", WordTypeProfanity))
+}
+
+func Test_Scan_Phrases(t *testing.T) {
+	d := newDetectorEN().
+		WithProfanePhrases([]string{"son of a bitch", "go ~1 kill|hurt yourself", "suck ~2 dick*"}).
+		WithSuspectPhrases([]string{"shut up"}).
+		WithFalsePositivePhrases([]string{"kill yourself laughing"})
+	var m Matches
+
+	// One match for the whole phrase
+	m = d.ScanAllProfanities("you son of a bitch!")
+	assert.Equal(t, &Match{Word: "son of a bitch", Start: 4, End: 18, WordType: WordTypeProfanity,
+		Text: []rune("son of a bitch"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, 1, len(m))
+	assert.True(t, m[0].Phrase)
+	assert.Equal(t, 4, m[0].ByteStart)
+	assert.Equal(t, 18, m[0].ByteEnd)
+
+	// Spaces, punctuation, leet speak and gaps
+	for _, text := range []string{"son   of-a b1tch", "SON OF A BITCH", "go kill yourself", "go and hurt... yourself!",
+		"suck my dick", "suck my big dicks"} {
+		m = d.ScanAllProfanities(text)
+		assert.Equal(t, 1, len(m), text)
+		assert.True(t, m[0].Phrase, text)
+	}
+	for _, text := range []string{"son of a gun", "go and then kill yourself", "suck my very big dick"} {
+		m = d.ScanAllProfanities(text)
+		assert.False(t, len(m) > 0 && m[0].Phrase, text)
+	}
+
+	// False positive phrases
+	m = d.ScanAllProfanities("go kill yourself laughing")
+	assert.Nil(t, m)
+
+	// Words outside the phrase are still matched
+	m = d.ScanAllProfanities("fuck, shut up")
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, "fuck", m[0].Word)
+	assert.Equal(t, "shut up", m[1].Word)
+	assert.True(t, m[1].IsSuspect())
+
+	m = d.ScanProfanity("x son of a bitch")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "son of a bitch", m[0].Word)
+
+	// Scanning stops at the first profane match
+	m = d.ScanProfanity("fuck you son of a bitch")
+	assert.Equal(t, 1, len(m))
+	assert.Equal(t, "fuck", m[0].Word)
+
+	// Profane words are not downgraded by suspect phrases
+	ds := newDetectorEN().WithSuspectPhrases([]string{"son of a bitch"})
+	assert.True(t, ds.IsProfane("you son of a bitch"))
+	m = ds.ScanAllProfanities("you son of a bitch")
+	assert.Equal(t, 2, len(m))
+	assert.True(t, m[0].Phrase && m[0].IsSuspect())
+	assert.Equal(t, "bitch", m[1].Word)
+	assert.True(t, m[1].IsProfane())
+}
+
+func Test_Censor_Phrases(t *testing.T) {
+	d := newDetectorEN().WithProfanePhrases([]string{"go ~1 kill yourself"})
+
+	s, _ := d.Censor("just go and kill yourself!")
+	assert.Equal(t, "just ** *** **** ********!", s)
+}
@@ -0,0 +1,106 @@
+package profanityout
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parameters of Punycode (RFC 3492)
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+	punycodeDelimiter   = '-'
+
+	// ACE prefix of internationalized domain name labels
+	idnaACEPrefix = "xn--"
+)
+
+var (
+	errInvalidPunycode = errors.New("profanityout: invalid punycode")
+)
+
+// decodePunycode decodes a Punycode string (without the "xn--" prefix) as defined in RFC 3492
+func decodePunycode(encoded string) (string, error) {
+	var output []rune
+	pos := 0
+	if basicEnd := strings.LastIndexByte(encoded, punycodeDelimiter); basicEnd >= 0 {
+		for i := 0; i < basicEnd; i++ {
+			if encoded[i] >= utf8.RuneSelf {
+				return "", errInvalidPunycode
+			}
+			output = append(output, rune(encoded[i]))
+		}
+		pos = basicEnd + 1
+	}
+
+	n, bias, i := punycodeInitialN, punycodeInitialBias, 0
+	for pos < len(encoded) {
+		oldI, weight := i, 1
+		for k := punycodeBase; ; k += punycodeBase {
+			if pos >= len(encoded) {
+				return "", errInvalidPunycode
+			}
+			digit := punycodeDigit(encoded[pos])
+			pos++
+			if digit < 0 || digit > (utf8.MaxRune-i)/weight {
+				return "", errInvalidPunycode
+			}
+			i += digit * weight
+			t := k - bias
+			if t < punycodeTMin {
+				t = punycodeTMin
+			} else if t > punycodeTMax {
+				t = punycodeTMax
+			}
+			if digit < t {
+				break
+			}
+			weight *= punycodeBase - t
+		}
+		numPoints := len(output) + 1
+		bias = punycodeAdapt(i-oldI, numPoints, oldI == 0)
+		n += i / numPoints
+		i %= numPoints
+		if n > utf8.MaxRune || n < punycodeInitialN {
+			return "", errInvalidPunycode
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), nil
+}
+
+func punycodeDigit(ch byte) int {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return int(ch-'0') + 26 //nolint:mnd
+	case ch >= 'a' && ch <= 'z':
+		return int(ch - 'a')
+	case ch >= 'A' && ch <= 'Z':
+		return int(ch - 'A')
+	}
+	return -1
+}
+
+func punycodeAdapt(delta, numPoints int, firstTime bool) int {
+	if firstTime {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
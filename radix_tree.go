@@ -5,6 +5,7 @@ import "strings"
 type tree struct {
 	root               *node
 	hasHeadingWildcard bool
+	maxWordLen         int // length in runes of the longest path
 }
 
 type node struct {
@@ -23,6 +24,7 @@ type WordFlag uint8
 const (
 	wordFlagRequireHeadSpace WordFlag = 1
 	wordFlagRequireTailSpace WordFlag = 2
+	wordFlagReversed         WordFlag = 4 // the path in the tree is the reverse of the word
 
 	wordFlagDefault WordFlag = wordFlagRequireHeadSpace | wordFlagRequireTailSpace
 )
@@ -51,6 +53,10 @@ func (flag *WordFlag) SetRequireTailSpace(val bool) {
 	}
 }
 
+func (flag WordFlag) Reversed() bool {
+	return flag&wordFlagReversed != 0
+}
+
 func (node *node) Next(next rune) *node {
 	if node.children == nil {
 		return nil
@@ -58,13 +64,32 @@ func (node *node) Next(next rune) *node {
 	return node.children[next]
 }
 
+// NextPath returns the node at the end of the path from the current node
+func (node *node) NextPath(path string) *node {
+	current := node
+	for _, ch := range path {
+		if current = current.Next(ch); current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
 func newTree() *tree {
 	return &tree{root: &node{children: make(map[rune]*node)}}
 }
 
 func (tree *tree) Add(word string, wordType WordType) {
-	word = normalizeAsNFC(word)
-	wordFlag := wordFlagDefault
+	tree.addEntry(removeEmojiModifiers(normalizeAsNFC(word)), wordType, wordFlagDefault)
+}
+
+// AddReversed adds the word in reversed order, this is used to detect words written backwards
+func (tree *tree) AddReversed(word string, wordType WordType) {
+	word = reverseString(removeEmojiModifiers(normalizeAsNFC(word)))
+	tree.addEntry(word, wordType, wordFlagDefault|wordFlagReversed)
+}
+
+func (tree *tree) addEntry(word string, wordType WordType, wordFlag WordFlag) {
 	for strings.HasPrefix(word, "*") {
 		word = strings.TrimPrefix(word, "*")
 		wordFlag.SetRequireHeadSpace(false)
@@ -76,16 +101,28 @@ func (tree *tree) Add(word string, wordType WordType) {
 	}
 
 	for _, w := range buildWordListHandleWildcard(word) {
-		tree.add(w, wordType, wordFlag)
+		if wordFlag.Reversed() {
+			tree.addPath(w, reverseString(w), wordType, wordFlag)
+		} else {
+			tree.add(w, wordType, wordFlag)
+		}
 	}
 }
 
 func (tree *tree) add(word string, wordType WordType, flag WordFlag) {
-	if len(word) == 0 {
+	tree.addPath(word, word, wordType, flag)
+}
+
+// addPath adds a path to the tree which represents the word. The path can be different from the word
+// when it is a variant of the word.
+func (tree *tree) addPath(path, word string, wordType WordType, flag WordFlag) {
+	if len(path) == 0 {
 		return
 	}
 	current := tree.root
-	for _, ch := range word {
+	wordLen := 0
+	for _, ch := range path {
+		wordLen++
 		next := current.Next(ch)
 		if next == nil {
 			next = &node{}
@@ -96,6 +133,9 @@ func (tree *tree) add(word string, wordType WordType, flag WordFlag) {
 		current.children[ch] = next
 		current = next
 	}
+	if wordLen > tree.maxWordLen {
+		tree.maxWordLen = wordLen
+	}
 	if current.word == nil {
 		current.word = &wordData{wordFlag: wordFlagDefault}
 	}
@@ -2,6 +2,7 @@ package profanityout
 
 import (
 	"unicode"
+	"unicode/utf8"
 )
 
 type scanner struct {
@@ -10,27 +11,94 @@ type scanner struct {
 	leetSpeakCharacters map[rune]rune
 	wildcardCharacters  map[rune]rune
 	profanityTree       *tree
+	skeletonTree        *tree // consonant skeletons of profane words, nil when they are not derived
 	falsePositiveTree   *tree
+	reversedTree        *tree
+
+	reversedFalsePositiveTree *tree
+
+	repeatSensitiveWords map[string]struct{}
+	emojiTree            *tree
+	phoneticIndex        phoneticIndex
+	commonWords          map[string]struct{}
+	phrases              []*phrase
+	falsePositivePhrases []*phrase
+	cooccurrenceRules    *cooccurrenceRules
+	wordWeights          map[string]float64
 
 	inputOrig []rune
 	input     []rune
+
+	// text which inputOrig is decoded from, used to calculate byte and UTF-16 offsets.
+	// inputOffsets are the offsets of the characters of inputOrig in the text when inputOrig
+	// is normalized from it, nil when the text is already normalized.
+	inputText    string
+	inputOffsets []textOffset
+	offsets      offsetCursor
+
+	// word edges found by the word segmenter, nil when segmentation is not enabled
+	segmentEdges []bool
+	// positions of URL parts where percent-encoded characters are decoded
+	percentEncoded []bool
+	// classes of the characters in markup processing modes, nil when no markup is processed
+	markup []markupClass
+
+	// position to start scanning from, characters before it are only used as context.
+	// startPrevCh is the character scanned before it when it's not 0.
+	startPos    int
+	startPrevCh rune
+	// position to stop scanning at when it's not 0, characters after it are only used as context.
+	// stopPos is the position where scanning stopped and stopPrevCh is the character scanned before it,
+	// scanning the input continues from there.
+	endPos     int
+	stopPos    int
+	stopPrevCh rune
+	// skipWord tells whether scanning starts inside a word which is skipped (see skipUntilWhitespace)
+	skipWord bool
+}
+
+func (s *scanner) scan(input string) Matches {
+	normalized, offsets := normalizeAsNFCWithOffsets(input)
+	return s.scanNormalized(input, []rune(normalized), offsets)
 }
 
-func (s *scanner) scan(input string) (matches Matches) {
+// scanNormalized scans the text normalized as NFC, the offsets are the offsets of the characters
+// of the normalized text in the text (see normalizeAsNFCWithOffsets)
+func (s *scanner) scanNormalized(text string, normalized []rune, offsets []textOffset) (matches Matches) {
+	s.inputText, s.inputOffsets = text, offsets
+	s.inputOrig = normalized
 	// Sanitizes accents if configured
 	if s.settings.SanitizeAccents {
-		s.inputOrig = []rune(input)
-		s.input = []rune(removeAccents(input))
+		s.input = []rune(removeAccents(string(normalized)))
 	} else {
-		s.inputOrig = []rune(normalizeAsNFC(input))
 		s.input = s.inputOrig
 	}
+	s.offsets = offsetCursor{}
+	s.applyURLPolicy()
+	s.processMarkup()
+	s.segmentWords()
 
 	match := Match{} // declares a match here to reduce the allocations
-	hasHeadingWildcard := s.settings.SanitizeWildcardCharacters && s.profanityTree.hasHeadingWildcard
+	hasHeadingWildcard := s.settings.SanitizeWildcardCharacters && (s.profanityTree.hasHeadingWildcard ||
+		(s.settings.DetectReversed && s.reversedTree.hasHeadingWildcard))
 	var prevCh rune
-	pos := 0
+	pos := s.startPos
+	if pos > 0 && pos <= len(s.input) {
+		prevCh = s.input[pos-1]
+	}
+	if s.startPrevCh != 0 {
+		prevCh = s.startPrevCh
+	}
+	if s.skipWord {
+		pos = s.skipUntilWhitespace(pos)
+	}
 	for {
+		if s.endPos > 0 && pos >= s.endPos {
+			break
+		}
+		if s.isMarkupAt(pos) && s.markup[pos] == markupHidden {
+			pos = s.skipMarkup(pos, markupHidden) // matches don't start with hidden markup
+		}
 		ch, nextPos := s.nextCharAt(pos)
 		if ch == 0 {
 			break
@@ -41,20 +109,39 @@ func (s *scanner) scan(input string) (matches Matches) {
 			continue
 		}
 
-		match = Match{Start: pos, HeadSpace: prevCh == 0 || s.isWhitespace(prevCh), Settings: s.settings}
+		match = Match{Start: pos, HeadSpace: prevCh == 0 || s.isWhitespace(prevCh) || s.isWordEdge(pos),
+			Settings: s.settings}
 		// Scans for a false positive first, if not found, scans for profanity
 		if s.scanFalsePositive(pos, s.falsePositiveTree.root, &match); match.WordType == 0 {
-			s.scanProfanity(pos, 0, s.profanityTree.root, &match)
+			s.scanProfanity(pos, 0, false, s.profanityTree.root, &match)
+			if match.WordType == 0 && s.skeletonTree != nil {
+				match.foundRealCharMatch = false
+				s.scanProfanity(pos, 0, false, s.skeletonTree.root, &match)
+			}
+			if match.WordType == 0 && s.settings.DetectReversed {
+				match.foundRealCharMatch = false
+				if s.scanFalsePositive(pos, s.reversedFalsePositiveTree.root, &match); match.WordType == 0 {
+					s.scanProfanity(pos, 0, false, s.reversedTree.root, &match)
+				}
+			}
+			if match.WordType == 0 && s.settings.FuzzyMaxEditDistance > 0 && match.HeadSpace {
+				s.scanFuzzy(pos, &match)
+			}
+			if match.WordType == 0 && s.settings.DetectPhonetic && match.HeadSpace {
+				s.scanPhonetic(pos, &match)
+			}
 		}
 
 		if match.WordType != 0 {
 			if !s.settings.ConfidenceCalculator(&match) {
 				goto ScanNextPos
 			}
+			s.updateMatchOffsets(&match)
 			matchCopy := match
 			matches = append(matches, &matchCopy)
-			if match.WordType == WordTypeProfanity && !s.settings.findAllProfanityMatches {
-				return matches
+			// Scanning continues when rules may combine the matches after the first profanity
+			if match.WordType == WordTypeProfanity && !s.settings.findAllProfanityMatches && !s.hasCooccurrenceRules() {
+				return s.firstProfaneMatches(s.scanPhrases(matches))
 			}
 			prevCh = ch
 			pos = match.End
@@ -63,7 +150,7 @@ func (s *scanner) scan(input string) (matches Matches) {
 
 	ScanNextPos:
 		prevCh = ch
-		if hasHeadingWildcard {
+		if hasHeadingWildcard || s.settings.MatchEmbeddedWords {
 			pos = nextPos
 			continue
 		}
@@ -73,10 +160,61 @@ func (s *scanner) scan(input string) (matches Matches) {
 		}
 		pos = nextPos
 	}
+	s.stopPos, s.stopPrevCh = pos, prevCh
+
+	return s.firstProfaneMatches(s.applyCooccurrenceRules(s.scanPhrases(matches)))
+}
 
+// firstProfaneMatches removes the matches after the first profane match when scanning for the first profanity
+func (s *scanner) firstProfaneMatches(matches Matches) Matches {
+	if s.settings.findAllProfanityMatches {
+		return matches
+	}
+	for i, match := range matches {
+		if match.IsProfane() {
+			return matches[:i+1]
+		}
+	}
 	return matches
 }
 
+func (s *scanner) segmentWords() {
+	s.segmentEdges = nil
+	var edges []int
+	if s.settings.SegmentWords {
+		segmenter := s.settings.WordSegmenter
+		if segmenter == nil {
+			// Uses the dictionaries of the detector for segmentation, false positives take precedence
+			segmenter = &dictionarySegmenter{trees: []*tree{s.falsePositiveTree, s.profanityTree}}
+		}
+		edges = segmenter.Segment(s.input)
+	}
+	if s.settings.SplitCompoundWords {
+		edges = append(edges, s.splitCompoundWords()...)
+	}
+	if len(edges) == 0 {
+		return
+	}
+	s.segmentEdges = make([]bool, len(s.input)+1)
+	for _, edge := range edges {
+		if edge >= 0 && edge <= len(s.input) {
+			s.segmentEdges[edge] = true
+		}
+	}
+}
+
+func (s *scanner) isSegmentEdge(i int) bool {
+	return s.segmentEdges != nil && i >= 0 && i < len(s.segmentEdges) && s.segmentEdges[i]
+}
+
+// isWordEdge checks if the position is an edge of a word which is not marked by a space
+func (s *scanner) isWordEdge(i int) bool {
+	if s.isSegmentEdge(i) {
+		return true
+	}
+	return s.settings.SanitizeEmoji && isEmojiEdgeAt(s.input, i)
+}
+
 func (s *scanner) shouldStartScanning(ch rune) bool {
 	if s.settings.SanitizeLeetSpeak && s.leetSpeakCharacters[ch] != 0 {
 		return true
@@ -84,9 +222,12 @@ func (s *scanner) shouldStartScanning(ch rune) bool {
 	return !s.isWhitespace(ch)
 }
 
+// scanProfanity walks the profanity tree from the current node.
+// `prevCh` is the last character matched and `repeated` tells whether any repeated character was collapsed.
+//
 //nolint:gocognit,gocyclo
-func (s *scanner) scanProfanity(pos int, prevCh rune, currentNode *node, match *Match) {
-	wildcardPos := -1
+func (s *scanner) scanProfanity(pos int, prevCh rune, repeated bool, currentNode *node, match *Match) {
+	wildcardNextPos := -1
 	var wildcardNode *node
 
 	for {
@@ -98,56 +239,70 @@ func (s *scanner) scanProfanity(pos int, prevCh rune, currentNode *node, match *
 		ch = unicode.ToLower(ch)
 		nextNode := currentNode.Next(ch)
 		if nextNode == nil { //nolint:nestif
+			if s.settings.SanitizeEmoji && s.emojiTree != nil {
+				if replacement, emojiEnd := s.emojiReplacementAt(pos); replacement != "" {
+					if emojiNode := currentNode.NextPath(replacement); emojiNode != nil {
+						match.foundRealCharMatch = true
+						if emojiNode.word != nil { // match found at the current node
+							s.updateProfanityMatch(match, emojiEnd, emojiNode, repeated)
+						}
+						lastCh, _ := utf8.DecodeLastRuneInString(replacement)
+						s.scanProfanity(emojiEnd, lastCh, repeated, emojiNode, match) // scan deeper
+						if match.WordType == WordTypeProfanity {                      // found a profanity, return
+							break
+						}
+					}
+				}
+			}
+
 			if s.settings.SanitizeLeetSpeak {
 				if lsCh, exists := s.leetSpeakCharacters[ch]; exists {
 					if lsNode := currentNode.Next(lsCh); lsNode != nil {
 						match.foundRealCharMatch = true
 						if lsNode.word != nil { // match found at the current node
-							s.updateMatchWithFoundNode(match, nextPos, lsNode)
+							s.updateProfanityMatch(match, nextPos, lsNode, repeated)
 						}
-						s.scanProfanity(nextPos, lsCh, lsNode, match) // scan deeper
-						if match.WordType == WordTypeProfanity {      // found a profanity, return
+						s.scanProfanity(nextPos, lsCh, repeated, lsNode, match) // scan deeper
+						if match.WordType == WordTypeProfanity {                // found a profanity, return
 							break
 						}
 					}
 				}
 			}
 
-			if !match.foundRealCharMatch {
+			if !match.foundRealCharMatch || s.isMarkupBreakAt(pos) {
 				break
 			}
 
 			if ch == ' ' && s.settings.SanitizeSpaces {
-				pos = nextPos
-				prevCh = ch
+				pos = nextPos // keeps prevCh to detect repeated characters separated by spaces
 				continue
 			}
 
-			if s.settings.SanitizeWildcardCharacters && wildcardPos == -1 {
+			if s.settings.SanitizeWildcardCharacters && wildcardNextPos == -1 {
 				if _, exists := s.wildcardCharacters[ch]; exists {
 					// Stores the pos we may start a new scan from when no matching found
-					wildcardPos, wildcardNode = pos, currentNode
+					wildcardNextPos, wildcardNode = nextPos, currentNode
 				}
 			}
 
 			if s.settings.SanitizeSpecialCharacters {
-				if specialCh, exists := s.specialCharacters[ch]; exists {
+				if specialCh, exists := s.specialCharOf(ch); exists {
 					ch = specialCh
 					nextNode = currentNode.Next(ch)
 					if nextNode != nil {
 						goto HandleNodeFound
 					}
 					if ch == ' ' && s.settings.SanitizeSpaces {
-						pos = nextPos
-						prevCh = ch
+						pos = nextPos // keeps prevCh to detect repeated characters separated by spaces
 						continue
 					}
 				}
 			}
 
-			if s.settings.SanitizeRepeatedCharacters && s.isCharRepeatedAt(pos, prevCh) {
+			if s.settings.SanitizeRepeatedCharacters && s.isCharRepeatedAt(match.Start, pos, prevCh) {
 				pos = nextPos
-				prevCh = ch
+				repeated = true
 				continue
 			}
 
@@ -171,18 +326,18 @@ func (s *scanner) scanProfanity(pos int, prevCh rune, currentNode *node, match *
 
 		// If there is a matching detected
 		if currentNode.word != nil {
-			s.updateMatchWithFoundNode(match, nextPos, currentNode)
+			s.updateProfanityMatch(match, nextPos, currentNode, repeated)
 		}
 	}
 
 	// After all scans and no matching found, we may start a new scan for wildcard matching
-	if match.WordType == 0 && wildcardPos >= 0 {
+	if match.WordType == 0 && wildcardNextPos >= 0 {
 		for currCh, currNode := range wildcardNode.children {
 			if currNode.word != nil { // match found at the current node
-				s.updateMatchWithFoundNode(match, wildcardPos+1, currNode)
+				s.updateProfanityMatch(match, wildcardNextPos, currNode, repeated)
 			}
-			s.scanProfanity(wildcardPos+1, currCh, currNode, match) // scan deeper
-			if match.WordType == WordTypeProfanity {                // found a profanity, return
+			s.scanProfanity(wildcardNextPos, currCh, repeated, currNode, match) // scan deeper
+			if match.WordType == WordTypeProfanity {                            // found a profanity, return
 				break
 			}
 		}
@@ -276,7 +431,7 @@ func (s *scanner) isWhitespace(ch rune) bool {
 		return true
 	}
 	if s.settings.SanitizeSpecialCharacters {
-		if ch = s.specialCharacters[ch]; ch == ' ' {
+		if ch, _ = s.specialCharOf(ch); ch == ' ' {
 			return true
 		}
 	}
@@ -292,28 +447,95 @@ func (s *scanner) isWhitespaceAt(i int) bool {
 		return true
 	}
 	if s.settings.SanitizeSpecialCharacters {
-		if ch2 := s.specialCharacters[ch]; ch2 != 0 {
+		if ch2, _ := s.specialCharOf(ch); ch2 != 0 {
 			ch = ch2
 		}
 	}
 	return ch == ' '
 }
 
-func (s *scanner) isCharRepeatedAt(i int, prevCh rune) bool {
+// isCharRepeatedAt checks if the character at the position repeats the previous matched character
+// and the run of the character is long enough to be collapsed. `start` is the start of the match.
+func (s *scanner) isCharRepeatedAt(start, i int, prevCh rune) bool {
 	if i <= 0 {
 		return false
 	}
-	ch, _ := s.nextCharAt(i)
-	return unicode.ToLower(ch) == prevCh
+	ch, next := s.nextCharAt(i)
+	if !s.isSameCharForRepetition(ch, prevCh) {
+		return false
+	}
+	minLength := s.settings.RepeatedCharactersMinLength
+	if minLength <= 2 { //nolint:mnd
+		return true
+	}
+
+	// Counts the characters of the run before and after the position, spaces in between are skipped
+	// when they are sanitized, so that "f u u u c k" is handled the same way as "fuuuck".
+	// The characters before are iterated from the match start the same way as scanning.
+	runLength := 1
+	for j := start; j < i; {
+		ch, nextJ := s.nextCharAt(j)
+		switch {
+		case ch == 0:
+			nextJ = i
+		case ch == ' ' && s.settings.SanitizeSpaces:
+		case s.isSameCharForRepetition(ch, prevCh):
+			runLength++
+		default:
+			runLength = 1
+		}
+		j = nextJ
+	}
+	for runLength < minLength {
+		ch, next = s.nextCharAt(next)
+		if ch == ' ' && s.settings.SanitizeSpaces {
+			continue
+		}
+		if ch == 0 || !s.isSameCharForRepetition(ch, prevCh) {
+			break
+		}
+		runLength++
+	}
+	return runLength >= minLength
+}
+
+// specialCharOf returns the replacement of the special character. Dashes of all kinds
+// ("—", "–", "&mdash;") are replaced the same way as '-'.
+func (s *scanner) specialCharOf(ch rune) (rune, bool) {
+	specialCh, exists := s.specialCharacters[ch]
+	if !exists && ch != '-' && unicode.Is(unicode.Pd, ch) {
+		specialCh, exists = s.specialCharacters['-']
+	}
+	return specialCh, exists
+}
+
+func (s *scanner) isSameCharForRepetition(ch rune, prevCh rune) bool {
+	ch = unicode.ToLower(ch)
+	if ch == prevCh {
+		return true
+	}
+	if !s.settings.SanitizeRepeatedLeetSpeak {
+		return false
+	}
+	if s.settings.SanitizeLeetSpeak && s.leetSpeakCharacters[ch] == prevCh {
+		return true
+	}
+	if s.settings.SanitizeSpecialCharacters {
+		if specialCh, exists := s.specialCharOf(ch); exists && specialCh == prevCh {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *scanner) skipUntilWhitespace(i int) int {
+	start := i
 	for {
 		ch, next := s.nextCharAt(i)
 		if ch == 0 {
 			return next
 		}
-		if ch == ' ' {
+		if ch == ' ' || (i != start && s.isWordEdge(i)) {
 			return i
 		}
 		if s.settings.SanitizeLeetSpeak {
@@ -322,7 +544,7 @@ func (s *scanner) skipUntilWhitespace(i int) int {
 			}
 		}
 		if s.settings.SanitizeSpecialCharacters {
-			if ch2 := s.specialCharacters[ch]; ch2 == ' ' {
+			if ch2, _ := s.specialCharOf(ch); ch2 == ' ' {
 				return i
 			}
 		}
@@ -330,6 +552,25 @@ func (s *scanner) skipUntilWhitespace(i int) int {
 	}
 }
 
+// emojiReplacementAt returns the replacement of the longest emoji sequence at the position
+func (s *scanner) emojiReplacementAt(pos int) (replacement string, end int) {
+	current := s.emojiTree.root
+	for {
+		ch, next := s.nextCharAt(pos)
+		if ch == 0 {
+			break
+		}
+		if current = current.Next(ch); current == nil {
+			break
+		}
+		pos = next
+		if current.word != nil {
+			replacement, end = current.word.word, pos
+		}
+	}
+	return replacement, end
+}
+
 func (s *scanner) nextCharAt(i int) (rune, int) {
 	return s.nextCharOf(s.input, i)
 }
@@ -343,23 +584,52 @@ func (s *scanner) nextCharOf(input []rune, i int) (rune, int) {
 		return 0, i
 	}
 	ch := input[i]
-	if s.settings.ProcessInputAsHTML { //nolint:nestif
-		if ch == '<' { // HTML tag opening
-			next := skipHTMLTag(input, i)
-			if next != i {
-				return s.nextCharOf(input, next)
-			}
-			return ch, i + 1
+	if s.isMarkupAt(i) {
+		if s.markup[i] == markupHidden {
+			return s.nextCharOf(input, s.skipMarkup(i, markupHidden))
 		}
-		if ch == '&' { // HTML entity beginning
-			ch2, next := decodeHTMLEntityAt(input, i)
-			if next == i {
-				return ch, i + 1
-			}
-			return ch2, next
+		return ' ', s.skipMarkup(i, s.markup[i])
+	}
+	if ch == '&' && s.settings.ProcessInputAsHTML { // HTML entity beginning
+		if ch2, next := decodeHTMLEntityAt(input, i); next != i {
+			return s.sanitizeDecodedChar(ch2), next
+		}
+	}
+	if ch == '%' && s.percentEncoded != nil && s.percentEncoded[i] {
+		if ch2, next := decodePercentEncodingAt(input, i); next != i {
+			return s.sanitizeDecodedChar(ch2), next
+		}
+	}
+	next := i + 1
+	if s.settings.SanitizeEmoji {
+		// Skin tones and variation selectors are part of the current character
+		next = skipEmojiModifiers(input, next)
+	}
+	if s.settings.SanitizeAccents {
+		// Combining marks left by accent removal are part of the current character
+		next = skipStrippedMarks(input, next)
+	}
+	return ch, next
+}
+
+// sanitizeDecodedChar sanitizes a character decoded from an entity or a percent-encoding
+// the same way as the input
+func (s *scanner) sanitizeDecodedChar(ch rune) rune {
+	if s.settings.SanitizeAccents {
+		ch = []rune(removeAccentsByChar(string(ch)))[0]
+	}
+	return ch
+}
+
+// updateProfanityMatch updates the match with the found node of the profanity tree.
+// Words which are sensitive to repetition are ignored when repeated characters were collapsed.
+func (s *scanner) updateProfanityMatch(match *Match, end int, node *node, repeated bool) {
+	if repeated {
+		if _, exists := s.repeatSensitiveWords[node.word.word]; exists {
+			return
 		}
 	}
-	return ch, i + 1
+	s.updateMatchWithFoundNode(match, end, node)
 }
 
 func (s *scanner) updateMatchWithFoundNode(match *Match, end int, node *node) {
@@ -369,8 +639,8 @@ func (s *scanner) updateMatchWithFoundNode(match *Match, end int, node *node) {
 		return
 	}
 
-	tailSpace := s.isWhitespaceAt(end)
-	if node.word.wordType < WordTypeFalsePositive {
+	tailSpace := s.isWhitespaceAt(end) || s.isWordEdge(end)
+	if node.word.wordType < WordTypeFalsePositive && !s.settings.MatchEmbeddedWords {
 		if !match.HeadSpace && node.word.wordFlag.RequireHeadSpace() {
 			return
 		}
@@ -382,6 +652,51 @@ func (s *scanner) updateMatchWithFoundNode(match *Match, end int, node *node) {
 	match.End = end
 	match.WordType = node.word.wordType
 	match.Word = node.word.word
+	match.Reversed = node.word.wordFlag.Reversed()
 	match.TailSpace = tailSpace
 	match.Text = s.inputOrig[match.Start:match.End]
 }
+
+// updateMatchOffsets calculates byte and UTF-16 offsets of the match in the input.
+// As matches are found in increasing order of position, the offset cursor only moves forward,
+// so the input is walked at most once no matter how many matches there are.
+func (s *scanner) updateMatchOffsets(match *Match) {
+	start, end := s.inputOffsetAt(match.Start, false), s.inputOffsetAt(match.End, true)
+	match.ByteStart, match.UTF16Start = start.byteOffset, start.utf16Offset
+	match.ByteEnd, match.UTF16End = end.byteOffset, end.utf16Offset
+}
+
+// inputOffsetAt returns the offsets of the character of inputOrig in the input. A position inside
+// a character normalized from several characters of the input is moved to the start of them,
+// or to the end of them when `roundUp` is set.
+func (s *scanner) inputOffsetAt(pos int, roundUp bool) textOffset {
+	if s.inputOffsets == nil {
+		byteOffset, utf16Offset := s.offsets.moveTo(s.inputText, pos)
+		return textOffset{byteOffset: byteOffset, utf16Offset: utf16Offset}
+	}
+	for roundUp && pos > 0 && pos < len(s.inputOffsets)-1 && s.inputOffsets[pos] == s.inputOffsets[pos-1] {
+		pos++
+	}
+	return s.inputOffsets[pos]
+}
+
+// offsetCursor maps rune indexes of a string to byte and UTF-16 offsets
+type offsetCursor struct {
+	pos         int
+	byteOffset  int
+	utf16Offset int
+}
+
+func (c *offsetCursor) moveTo(text string, pos int) (byteOffset, utf16Offset int) {
+	if pos < c.pos {
+		*c = offsetCursor{}
+	}
+	for c.pos < pos && c.byteOffset < len(text) {
+		// Decodes the same way as converting a string to []rune, so invalid bytes are counted one by one
+		ch, size := utf8.DecodeRuneInString(text[c.byteOffset:])
+		c.pos++
+		c.byteOffset += size
+		c.utf16Offset += utf16Len(ch)
+	}
+	return c.byteOffset, c.utf16Offset
+}
@@ -0,0 +1,126 @@
+package profanityout
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Score is the score of a text calculated by ScoreText with its breakdown.
+// Value equals (Weight + Obfuscation + Repetition) * LengthFactor.
+type Score struct {
+	Value   float64
+	Entries []*ScoreEntry
+	Matches Matches // all matches of the scan
+
+	ProfaneCount int
+	SuspectCount int
+
+	Weight       float64 // sum of the weights of the matches
+	Obfuscation  float64 // sum of the bonuses added for obfuscated matches
+	Repetition   float64 // sum of the amounts reduced for repeated words (zero or negative)
+	Words        int     // number of words of the text
+	LengthFactor float64 // factor reducing the score of long texts, 1 for short texts
+}
+
+// ScoreEntry is the score of a profane or suspect match
+type ScoreEntry struct {
+	Match       *Match
+	Weight      float64 // weight of the word, or the weight of the word type
+	Obfuscation float64 // ratio of the characters changed from the word, between 0 and 1
+	Repetition  int     // number of the previous matches of the same word
+	Value       float64 // Weight * (1 + ScoreObfuscationWeight * Obfuscation) * ScoreRepetitionDecay ^ Repetition
+}
+
+// ScoreText scans the text for all profanities the same way as ScanAllProfanities and scores it.
+// Each profane and suspect match has the weight set by WithWordWeights, or ScoreProfaneWeight and
+// ScoreSuspectWeight by its type. The weight is increased by the obfuscation effort of the match ("f_u_c_k"),
+// and decayed by ScoreRepetitionDecay for each previous match of the same word. Scores of texts longer than
+// ScoreLengthReference words are reduced by the factor sqrt(ScoreLengthReference / words).
+//
+// For instance, with the default settings "fuck" scores 1 and "f.u.c.k" scores about 1.21.
+func (d *ProfanityDetector) ScoreText(s string, options ...DetectorOption) *Score {
+	scanner := d.newScanner(true, options...)
+	matches := scanner.scan(s)
+	return scanner.score(matches)
+}
+
+func (s *scanner) score(matches Matches) *Score {
+	settings := s.settings
+	score := &Score{Matches: matches, Words: len(s.readPhraseTokens()), LengthFactor: 1}
+	repetitions := map[string]int{}
+	for _, match := range matches {
+		if !match.IsProfane() && !match.IsSuspect() {
+			continue
+		}
+		word := strings.ToLower(match.Word)
+		entry := &ScoreEntry{Match: match, Weight: settings.ScoreSuspectWeight, Repetition: repetitions[word]}
+		if match.IsProfane() {
+			entry.Weight = settings.ScoreProfaneWeight
+			score.ProfaneCount++
+		} else {
+			score.SuspectCount++
+		}
+		if weight, exists := s.wordWeights[word]; exists {
+			entry.Weight = weight
+		}
+		entry.Obfuscation = matchObfuscation(match)
+		repetitions[word]++
+
+		value := entry.Weight * (1 + settings.ScoreObfuscationWeight*entry.Obfuscation)
+		entry.Value = value * math.Pow(settings.ScoreRepetitionDecay, float64(entry.Repetition))
+		score.Weight += entry.Weight
+		score.Obfuscation += value - entry.Weight
+		score.Repetition += entry.Value - value
+		score.Entries = append(score.Entries, entry)
+	}
+	if settings.ScoreLengthReference > 0 && score.Words > settings.ScoreLengthReference {
+		score.LengthFactor = math.Sqrt(float64(settings.ScoreLengthReference) / float64(score.Words))
+	}
+	score.Value = (score.Weight + score.Obfuscation + score.Repetition) * score.LengthFactor
+	return score
+}
+
+// matchObfuscation calculates the ratio of the characters of the match text changed from the word.
+// Spaces are not counted, reversed matches are fully obfuscated, phrases and co-occurrences are not.
+func matchObfuscation(match *Match) float64 {
+	if match.Phrase || match.Cooccurrence {
+		return 0
+	}
+	if match.Reversed {
+		return 1
+	}
+	text := make([]rune, 0, len(match.Text))
+	for _, ch := range removeAccents(strings.ToLower(string(match.Text))) {
+		if !unicode.IsSpace(ch) {
+			text = append(text, ch)
+		}
+	}
+	word := []rune(strings.ToLower(match.Word))
+	length := maxInt(len(text), len(word))
+	if length == 0 {
+		return 0
+	}
+	return float64(editDistance(text, word)) / float64(length)
+}
+
+// editDistance calculates the Levenshtein distance between the texts
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,68 @@
+package profanityout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_editDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance([]rune("fuck"), []rune("fuck")))
+	assert.Equal(t, 3, editDistance([]rune("f.u.c.k"), []rune("fuck")))
+	assert.Equal(t, 2, editDistance([]rune("$h1t"), []rune("shit")))
+	assert.Equal(t, 4, editDistance(nil, []rune("fuck")))
+}
+
+func Test_ScoreText(t *testing.T) {
+	d := newDetectorEN().WithSuspectWords([]string{"idiot"})
+	var score *Score
+
+	score = d.ScoreText("fuck")
+	assert.Equal(t, 1.0, score.Value)
+	assert.Equal(t, 1, score.ProfaneCount)
+	assert.Equal(t, 1, len(score.Entries))
+	assert.Equal(t, &ScoreEntry{Match: score.Matches[0], Weight: 1, Value: 1}, score.Entries[0])
+
+	// Obfuscation
+	score = d.ScoreText("f.u.c.k")
+	assert.InDelta(t, 3.0/7, score.Entries[0].Obfuscation, 1e-9)
+	assert.InDelta(t, 0.5*3/7, score.Obfuscation, 1e-9)
+	assert.InDelta(t, 1+0.5*3/7, score.Value, 1e-9)
+
+	// Suspects and repetition
+	score = d.ScoreText("idiot fuck fuck fuck")
+	assert.Equal(t, 1, score.SuspectCount)
+	assert.Equal(t, 3, score.ProfaneCount)
+	assert.Equal(t, 2, score.Entries[3].Repetition)
+	assert.InDelta(t, 3.3, score.Weight, 1e-9)
+	assert.InDelta(t, -0.5-0.75, score.Repetition, 1e-9)
+	assert.InDelta(t, 0.3+1+0.5+0.25, score.Value, 1e-9)
+
+	// Length
+	score = d.ScoreText("fuck" + strings.Repeat(" hello", 79))
+	assert.Equal(t, 80, score.Words)
+	assert.InDelta(t, 0.5, score.LengthFactor, 1e-9)
+	assert.InDelta(t, 0.5, score.Value, 1e-9)
+
+	// No matches
+	score = d.ScoreText("hello world")
+	assert.Equal(t, 0.0, score.Value)
+	assert.Equal(t, 0, len(score.Entries))
+	assert.Equal(t, 1.0, score.LengthFactor)
+}
+
+func Test_ScoreText_Settings(t *testing.T) {
+	d := newDetectorEN().WithSuspectWords([]string{"idiot"}).
+		WithWordWeights(map[string]float64{"Fuck": 3, "son of a bitch": 4}).
+		WithProfanePhrases([]string{"son of a bitch"})
+
+	assert.Equal(t, 3.0, d.ScoreText("fuck").Value)
+	assert.Equal(t, 4.0, d.ScoreText("son of a b1tch").Value)
+	assert.Equal(t, 1.0, d.ScoreText("shit", WithScoreProfaneWeight(1)).Value)
+	assert.Equal(t, 2.0, d.ScoreText("shit", WithScoreProfaneWeight(2)).Value)
+	assert.Equal(t, 1.0, d.ScoreText("idiot", WithScoreSuspectWeight(1)).Value)
+	assert.Equal(t, 3.0, d.ScoreText("f.u.c.k", WithScoreObfuscationWeight(0)).Value)
+	assert.Equal(t, 9.0, d.ScoreText("fuck fuck fuck", WithScoreRepetitionDecay(1)).Value)
+	assert.Equal(t, 1.0, d.ScoreText("shit"+strings.Repeat(" hello", 99), WithScoreLengthReference(0)).Value)
+}
@@ -0,0 +1,106 @@
+package profanityout
+
+import (
+	"unicode"
+)
+
+// WordSegmenter splits text of languages which don't use spaces between words
+// (such as Chinese, Japanese or Thai) into words.
+type WordSegmenter interface {
+	// Segment returns positions (rune indexes) of word edges in the input in ascending order
+	Segment(input []rune) []int
+}
+
+var (
+	// noSpaceScripts are scripts written without spaces between words, their combining marks
+	// (such as Thai vowel and tone marks) are parts of the words
+	noSpaceScripts = []*unicode.RangeTable{
+		unicode.Han,
+		unicode.Hiragana,
+		unicode.Katakana,
+		unicode.Thai,
+		unicode.Lao,
+		unicode.Khmer,
+		unicode.Myanmar,
+		unicode.Tibetan,
+	}
+)
+
+// isNoSpaceScriptChar checks if a character belongs to a script which doesn't use spaces between words
+func isNoSpaceScriptChar(ch rune) bool {
+	if ch < 0x0E00 { // fast path for Latin, Greek, Cyrillic...
+		return false
+	}
+	if ch == 'ー' || ch == '々' { // prolonged sound mark and iteration mark belong to the common script
+		return true
+	}
+	return unicode.In(ch, noSpaceScripts...)
+}
+
+// dictionarySegmenter splits runs of no-space-script characters by the longest matching words
+// found in the dictionaries. Characters that don't belong to any known word are grouped together.
+// A word found first takes the characters it covers, so when the segmenter only knows the profanity and
+// false positive dictionaries (the default), a false positive overlapping a profanity next to it
+// hides the profanity: with the false positive "是傻", "傻逼" isn't found in "你是傻逼吗".
+type dictionarySegmenter struct {
+	trees []*tree
+}
+
+// NewDictionaryWordSegmenter creates a word segmenter for Chinese, Japanese, Thai, Lao, Khmer, Myanmar
+// and Tibetan text. The segmenter uses the longest matching strategy on the given words. Other text is
+// not segmented as spaces are used to separate words.
+func NewDictionaryWordSegmenter(words []string) WordSegmenter {
+	wordTree := newTree()
+	for _, word := range words {
+		wordTree.Add(word, WordTypeFalsePositive)
+	}
+	return &dictionarySegmenter{trees: []*tree{wordTree}}
+}
+
+func (seg *dictionarySegmenter) Segment(input []rune) (edges []int) {
+	length := len(input)
+	for i := 0; i < length; {
+		if !isNoSpaceScriptChar(input[i]) {
+			i++
+			continue
+		}
+		// Found a run of no-space-script characters
+		runStart, runEnd := i, i+1
+		for runEnd < length && isNoSpaceScriptChar(input[runEnd]) {
+			runEnd++
+		}
+		edges = append(edges, runStart)
+		for j := runStart; j < runEnd; {
+			wordLen := seg.longestWordAt(input[:runEnd], j)
+			if wordLen == 0 {
+				j++
+				continue
+			}
+			if edges[len(edges)-1] != j {
+				edges = append(edges, j)
+			}
+			j += wordLen
+			edges = append(edges, j)
+		}
+		if edges[len(edges)-1] != runEnd {
+			edges = append(edges, runEnd)
+		}
+		i = runEnd
+	}
+	return edges
+}
+
+func (seg *dictionarySegmenter) longestWordAt(input []rune, pos int) (wordLen int) {
+	for _, wordTree := range seg.trees {
+		current := wordTree.root
+		for i := pos; i < len(input); i++ {
+			if current = current.Next(unicode.ToLower(input[i])); current == nil {
+				break
+			}
+			if current.word != nil && i+1-pos > wordLen {
+				wordLen = i + 1 - pos
+			}
+		}
+	}
+	return wordLen
+}
@@ -0,0 +1,51 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dictionarySegmenter(t *testing.T) {
+	seg := NewDictionaryWordSegmenter([]string{"傻逼", "你好", "ควย"})
+
+	assert.Nil(t, seg.Segment([]rune("hello world")))
+	assert.Equal(t, []int{0, 2, 3}, seg.Segment([]rune("你好吗")))
+	assert.Equal(t, []int{0, 1, 3, 4}, seg.Segment([]rune("是傻逼吗")))
+	assert.Equal(t, []int{4, 6, 9}, seg.Segment([]rune("abc 你好ควย abc")))
+	assert.Equal(t, []int{2, 5}, seg.Segment([]rune("x ไอ้ y")))
+}
+
+func Test_Scan_SegmentWords(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().
+			WithProfaneWords([]string{"傻逼", "ควย", "くそ"}).
+			WithFalsePositiveWords([]string{"くそう"})
+	}
+	var m Matches
+
+	m = d().WithSegmentWords(false).ScanAllProfanities("你是傻逼吗")
+	assert.Nil(t, m)
+
+	m = d().WithSegmentWords(true).ScanAllProfanities("你是傻逼吗")
+	assert.Equal(t, &Match{Word: "傻逼", Start: 2, End: 4, WordType: WordTypeProfanity,
+		Text: []rune("傻逼"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	m = d().ScanAllProfanities("ไอ้ควยเอ๊ย", WithSegmentWords(true))
+	assert.Equal(t, &Match{Word: "ควย", Start: 3, End: 6, WordType: WordTypeProfanity,
+		Text: []rune("ควย"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+
+	// False positive takes precedence as it is the longest word
+	m = d().WithSegmentWords(true).ScanAllProfanities("ちくそう")
+	assert.False(t, m.HasProfaneMatch())
+
+	// Mixing with space separated languages
+	m = d().WithSegmentWords(true).ScanAllProfanities("fuck傻逼")
+	assert.Equal(t, 2, len(m.GetProfaneMatches()))
+
+	// Custom segmenter
+	m = d().WithSegmentWords(true).
+		WithWordSegmenter(NewDictionaryWordSegmenter([]string{"是傻"})).
+		ScanAllProfanities("你是傻逼吗")
+	assert.Nil(t, m)
+}
@@ -7,7 +7,81 @@ type DetectorSettings struct {
 	SanitizeSpaces             bool
 	SanitizeRepeatedCharacters bool
 	SanitizeWildcardCharacters bool
+	SanitizeEmoji              bool
 	ProcessInputAsHTML         bool
+	ProcessInputAsMarkdown     bool
+	ProcessInputAsBBCode       bool
+	// BBCodeExcludeQuotes excludes quoted content ([quote]...[/quote]) from scanning in BBCode mode
+	BBCodeExcludeQuotes bool
+
+	// RepeatedCharactersMinLength is the minimum length of a run of the same character to be collapsed
+	// when SanitizeRepeatedCharacters is on. Values less than 2 are treated as 2.
+	RepeatedCharactersMinLength int
+	// SanitizeRepeatedLeetSpeak allows collapsing repeated leet speak and special characters ("$$hit")
+	SanitizeRepeatedLeetSpeak bool
+
+	// DetectReversed enables detection of words written backwards
+	DetectReversed bool
+
+	// FuzzyMaxEditDistance enables fuzzy matching of words when it's greater than 0. Words are matched
+	// when the number of insertions, deletions, substitutions and transpositions is within this value.
+	// Only words whose length is at least FuzzyMinWordLength (default: 4) are matched this way.
+	FuzzyMaxEditDistance int
+	FuzzyMinWordLength   int
+
+	// DetectPhonetic enables matching of words which sound the same as dictionary words.
+	// PhoneticMatchType is the word type of phonetic matches (default: WordTypeSuspect), the type of
+	// the dictionary word is used when it's 0. Phonetic keys shorter than PhoneticMinKeyLength
+	// (default: 3) are not matched.
+	DetectPhonetic       bool
+	PhoneticMatchType    WordType
+	PhoneticMinKeyLength int
+
+	// SegmentWords enables word segmentation for text without spaces between words (Chinese, Thai...),
+	// word edges found by the segmenter are treated the same way as spaces.
+	// WordSegmenter is used when set, otherwise a dictionary-based segmenter is used.
+	SegmentWords  bool
+	WordSegmenter WordSegmenter
+
+	// MatchEmbeddedWords enables matching of dictionary words inside other words,
+	// the space requirements of dictionary words are ignored
+	MatchEmbeddedWords bool
+
+	// SplitCompoundWords enables splitting of hashtags, camelCase, snake_case and concatenated words
+	// into words. Word edges are then treated the same way as spaces.
+	SplitCompoundWords bool
+
+	// URLPolicy defines how URLs and email addresses in the input are scanned (default: URLPolicyScan).
+	// URLPercentDecode enables decoding of percent-encoded characters in the scanned parts of them.
+	URLPolicy        URLPolicy
+	URLPercentDecode bool
+
+	// JSONScanKeys enables scanning of object keys in JSON documents.
+	// JSONIncludePaths and JSONExcludePaths are JSON Pointers (RFC 6901) of the values scanned or not,
+	// descendants of the values are matched too and a "*" segment matches any key or index.
+	JSONScanKeys     bool
+	JSONIncludePaths []string
+	JSONExcludePaths []string
+
+	// FieldSeparator joins the texts of fields scanned together by ScanFields and CensorFields (default: " ")
+	FieldSeparator string
+
+	// StreamLookback is the number of characters kept when scanning a stream to detect
+	// matches crossing chunk boundaries. When it is 0, the value is calculated from the
+	// longest dictionary word.
+	StreamLookback int
+
+	// ScoreProfaneWeight and ScoreSuspectWeight are the weights of profane and suspect matches in scores
+	// calculated by ScoreText (defaults: 1 and 0.3), words can have their own weights (see WithWordWeights).
+	// ScoreObfuscationWeight is the bonus of fully obfuscated matches relative to the weight (default: 0.5).
+	// ScoreRepetitionDecay multiplies the value of a match for each previous match of the word (default: 0.5).
+	// ScoreLengthReference is the number of words from which scores of longer texts are reduced
+	// (default: 20, 0 disables the reduction).
+	ScoreProfaneWeight     float64
+	ScoreSuspectWeight     float64
+	ScoreObfuscationWeight float64
+	ScoreRepetitionDecay   float64
+	ScoreLengthReference   int
 
 	ConfidenceCalculator ConfidenceCalculator
 	CensorCharacter      rune
@@ -43,18 +117,186 @@ func WithSanitizeRepeatedCharacters(flag bool) DetectorOption {
 	}
 }
 
+func WithRepeatedCharactersMinLength(minLength int) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.RepeatedCharactersMinLength = minLength
+	}
+}
+
+func WithSanitizeRepeatedLeetSpeak(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.SanitizeRepeatedLeetSpeak = flag
+	}
+}
+
 func WithSanitizeWildcardCharacters(flag bool) DetectorOption {
 	return func(settings *DetectorSettings) {
 		settings.SanitizeWildcardCharacters = flag
 	}
 }
 
+func WithSanitizeEmoji(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.SanitizeEmoji = flag
+	}
+}
+
 func WithProcessInputAsHTML(flag bool) DetectorOption {
 	return func(settings *DetectorSettings) {
 		settings.ProcessInputAsHTML = flag
 	}
 }
 
+func WithProcessInputAsMarkdown(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ProcessInputAsMarkdown = flag
+	}
+}
+
+func WithProcessInputAsBBCode(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ProcessInputAsBBCode = flag
+	}
+}
+
+func WithBBCodeExcludeQuotes(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.BBCodeExcludeQuotes = flag
+	}
+}
+
+func WithDetectReversed(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.DetectReversed = flag
+	}
+}
+
+func WithFuzzyMaxEditDistance(maxDistance int) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.FuzzyMaxEditDistance = maxDistance
+	}
+}
+
+func WithFuzzyMinWordLength(minLength int) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.FuzzyMinWordLength = minLength
+	}
+}
+
+func WithDetectPhonetic(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.DetectPhonetic = flag
+	}
+}
+
+func WithPhoneticMatchType(wordType WordType) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.PhoneticMatchType = wordType
+	}
+}
+
+func WithPhoneticMinKeyLength(minLength int) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.PhoneticMinKeyLength = minLength
+	}
+}
+
+func WithSegmentWords(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.SegmentWords = flag
+	}
+}
+
+func WithWordSegmenter(segmenter WordSegmenter) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.WordSegmenter = segmenter
+	}
+}
+
+func WithMatchEmbeddedWords(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.MatchEmbeddedWords = flag
+	}
+}
+
+func WithSplitCompoundWords(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.SplitCompoundWords = flag
+	}
+}
+
+func WithURLPolicy(policy URLPolicy) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.URLPolicy = policy
+	}
+}
+
+func WithURLPercentDecode(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.URLPercentDecode = flag
+	}
+}
+
+func WithStreamLookback(lookback int) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.StreamLookback = lookback
+	}
+}
+
+func WithJSONScanKeys(flag bool) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.JSONScanKeys = flag
+	}
+}
+
+func WithJSONIncludePaths(paths ...string) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.JSONIncludePaths = paths
+	}
+}
+
+func WithJSONExcludePaths(paths ...string) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.JSONExcludePaths = paths
+	}
+}
+
+func WithFieldSeparator(separator string) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.FieldSeparator = separator
+	}
+}
+
+func WithScoreProfaneWeight(weight float64) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ScoreProfaneWeight = weight
+	}
+}
+
+func WithScoreSuspectWeight(weight float64) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ScoreSuspectWeight = weight
+	}
+}
+
+func WithScoreObfuscationWeight(weight float64) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ScoreObfuscationWeight = weight
+	}
+}
+
+func WithScoreRepetitionDecay(decay float64) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ScoreRepetitionDecay = decay
+	}
+}
+
+func WithScoreLengthReference(words int) DetectorOption {
+	return func(settings *DetectorSettings) {
+		settings.ScoreLengthReference = words
+	}
+}
+
 func WithConfidenceCalculator(fn ConfidenceCalculator) DetectorOption {
 	return func(settings *DetectorSettings) {
 		settings.ConfidenceCalculator = fn
@@ -21,12 +21,76 @@ func Test_Settings(t *testing.T) {
 	WithSanitizeRepeatedCharacters(true)(s)
 	assert.Equal(t, true, s.SanitizeRepeatedCharacters)
 
+	WithRepeatedCharactersMinLength(3)(s)
+	assert.Equal(t, 3, s.RepeatedCharactersMinLength)
+
+	WithSanitizeRepeatedLeetSpeak(true)(s)
+	assert.Equal(t, true, s.SanitizeRepeatedLeetSpeak)
+
 	WithSanitizeWildcardCharacters(true)(s)
 	assert.Equal(t, true, s.SanitizeWildcardCharacters)
 
+	WithSanitizeEmoji(true)(s)
+	assert.Equal(t, true, s.SanitizeEmoji)
+
+	WithDetectReversed(true)(s)
+	assert.Equal(t, true, s.DetectReversed)
+
+	WithFuzzyMaxEditDistance(2)(s)
+	assert.Equal(t, 2, s.FuzzyMaxEditDistance)
+
+	WithFuzzyMinWordLength(5)(s)
+	assert.Equal(t, 5, s.FuzzyMinWordLength)
+
+	WithDetectPhonetic(true)(s)
+	assert.Equal(t, true, s.DetectPhonetic)
+
+	WithPhoneticMatchType(WordTypeSuspect)(s)
+	assert.Equal(t, WordTypeSuspect, s.PhoneticMatchType)
+
+	WithPhoneticMinKeyLength(4)(s)
+	assert.Equal(t, 4, s.PhoneticMinKeyLength)
+
+	WithMatchEmbeddedWords(true)(s)
+	assert.Equal(t, true, s.MatchEmbeddedWords)
+
+	WithSplitCompoundWords(true)(s)
+	assert.Equal(t, true, s.SplitCompoundWords)
+
+	WithURLPolicy(URLPolicyHostAndPath)(s)
+	assert.Equal(t, URLPolicyHostAndPath, s.URLPolicy)
+
+	WithURLPercentDecode(true)(s)
+	assert.Equal(t, true, s.URLPercentDecode)
+
 	WithProcessInputAsHTML(true)(s)
 	assert.Equal(t, true, s.ProcessInputAsHTML)
 
+	WithProcessInputAsMarkdown(true)(s)
+	assert.Equal(t, true, s.ProcessInputAsMarkdown)
+	WithFieldSeparator("|")(s)
+	assert.Equal(t, "|", s.FieldSeparator)
+	WithJSONScanKeys(true)(s)
+	assert.Equal(t, true, s.JSONScanKeys)
+	WithJSONIncludePaths("/a")(s)
+	assert.Equal(t, []string{"/a"}, s.JSONIncludePaths)
+	WithJSONExcludePaths("/b")(s)
+	assert.Equal(t, []string{"/b"}, s.JSONExcludePaths)
+	WithProcessInputAsBBCode(true)(s)
+	assert.Equal(t, true, s.ProcessInputAsBBCode)
+	WithBBCodeExcludeQuotes(true)(s)
+	assert.Equal(t, true, s.BBCodeExcludeQuotes)
+	WithScoreProfaneWeight(2)(s)
+	assert.Equal(t, 2.0, s.ScoreProfaneWeight)
+	WithScoreSuspectWeight(0.5)(s)
+	assert.Equal(t, 0.5, s.ScoreSuspectWeight)
+	WithScoreObfuscationWeight(0)(s)
+	assert.Equal(t, 0.0, s.ScoreObfuscationWeight)
+	WithScoreRepetitionDecay(1)(s)
+	assert.Equal(t, 1.0, s.ScoreRepetitionDecay)
+	WithScoreLengthReference(50)(s)
+	assert.Equal(t, 50, s.ScoreLengthReference)
+
 	WithCensorCharacter('%')(s)
 	assert.Equal(t, '%', s.CensorCharacter)
 }
@@ -0,0 +1,101 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultConsonantSkeletonMinLength = 3
+)
+
+// consonantSkeleton removes all vowels from the word except the leading one.
+// For instance, "fuck" -> "fck", "bitch" -> "btch", "asshole" -> "asshl".
+func consonantSkeleton(word string) string {
+	var sb strings.Builder
+	for i, ch := range word {
+		if i > 0 && strings.ContainsRune("aeiou", ch) {
+			continue
+		}
+		sb.WriteRune(ch)
+	}
+	return sb.String()
+}
+
+// walk calls the function for every word in the tree with the path leading to it
+func (tree *tree) walk(fn func(path string, word *wordData)) {
+	var path []rune
+	var walkNode func(current *node)
+	walkNode = func(current *node) {
+		if current.word != nil {
+			fn(string(path), current.word)
+		}
+		for ch, child := range current.children {
+			path = append(path, ch)
+			walkNode(child)
+			path = path[:len(path)-1]
+		}
+	}
+	walkNode(tree.root)
+}
+
+// consonantSkeletonTree returns the tree of the consonant skeletons, nil when they are not derived.
+// The tree is built on first use, after all words and settings are set, so the result doesn't depend
+// on the order of the configuration calls.
+func (d *ProfanityDetector) consonantSkeletonTree() *tree {
+	if d.skeletonMinLength <= 0 {
+		return nil
+	}
+	d.skeletonMu.Lock()
+	defer d.skeletonMu.Unlock()
+	if d.skeletonTree == nil {
+		d.skeletonTree = d.buildConsonantSkeletons()
+	}
+	return d.skeletonTree
+}
+
+// buildConsonantSkeletons derives the consonant skeletons of all profane words and adds them
+// to a tree with their base words. Skeletons which are too short or ambiguous are skipped.
+func (d *ProfanityDetector) buildConsonantSkeletons() *tree {
+	skeletonTree := newTree()
+
+	// Skeletons of common words and false positives are reserved
+	reserved := make(map[string]struct{}, len(d.commonWords))
+	for word := range d.commonWords {
+		reserved[word] = struct{}{}
+		reserved[consonantSkeleton(word)] = struct{}{}
+	}
+	d.falsePositiveTree.walk(func(path string, word *wordData) {
+		reserved[path] = struct{}{}
+		reserved[consonantSkeleton(path)] = struct{}{}
+	})
+
+	// Skeletons are derived in order of the words added, the first word wins when words share a skeleton
+	derived := make(map[string]struct{})
+	for _, word := range d.profaneWords {
+		word = strings.Trim(removeEmojiModifiers(normalizeAsNFC(word)), "*")
+		if strings.Contains(word, "*") {
+			continue // wildcard variants are not used
+		}
+		skeleton := consonantSkeleton(removeAccents(word))
+		if skeleton == word || utf8.RuneCountInString(skeleton) < d.skeletonMinLength {
+			continue
+		}
+		if _, exists := derived[skeleton]; exists {
+			continue
+		}
+		derived[skeleton] = struct{}{}
+		if _, exists := reserved[skeleton]; exists {
+			continue
+		}
+		if node := d.profanityTree.root.NextPath(skeleton); node != nil && node.word != nil {
+			continue // already a dictionary word
+		}
+		base := d.profanityTree.root.NextPath(word)
+		if base == nil || base.word == nil {
+			continue
+		}
+		skeletonTree.addPath(skeleton, base.word.word, base.word.wordType, base.word.wordFlag)
+	}
+	return skeletonTree
+}
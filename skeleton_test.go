@@ -0,0 +1,69 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_ConsonantSkeleton(t *testing.T) {
+	assert.Equal(t, "fck", consonantSkeleton("fuck"))
+	assert.Equal(t, "btch", consonantSkeleton("bitch"))
+	assert.Equal(t, "asshl", consonantSkeleton("asshole"))
+	assert.Equal(t, "sxy", consonantSkeleton("sexy"))
+}
+
+func Test_Scan_ConsonantSkeletons(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithCommonWords(en.DefaultCommonWords)
+	}
+	var m Matches
+
+	m = d().WithConsonantSkeletons(3).ScanAllProfanities("x prn, pssy")
+	assert.Equal(t, &Match{Word: "porn", Start: 2, End: 5, WordType: WordTypeProfanity,
+		Text: []rune("prn"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, "pussy", m[1].Word)
+
+	// Sanitization is applied the same way
+	m = d().WithConsonantSkeletons(3).ScanAllProfanities("x p.r.n")
+	assert.Equal(t, "porn", m[0].Word)
+
+	// The first word wins when words share a skeleton
+	m = NewProfanityDetector().WithProfaneWords([]string{"fuck", "feck"}).
+		WithConsonantSkeletons(3).ScanAllProfanities("x fck")
+	assert.Equal(t, "fuck", m[0].Word)
+
+	// Skeletons added later are derived as well
+	m = NewProfanityDetector().WithConsonantSkeletons(3).
+		WithProfaneWords([]string{"fuck"}).ScanAllProfanities("x fck")
+	assert.Equal(t, "fuck", m[0].Word)
+
+	// The order of the configuration calls doesn't matter
+	m = NewProfanityDetector().WithConsonantSkeletons(3).WithProfaneWords([]string{"shit"}).
+		WithFalsePositiveWords([]string{"shot"}).ScanAllProfanities("x sht")
+	assert.Nil(t, m)
+	m = newDetectorEN().WithConsonantSkeletons(3).WithCommonWords(en.DefaultCommonWords).
+		ScanAllProfanities("x sht cnt dck")
+	assert.Nil(t, m)
+
+	// Ambiguous skeletons are skipped: "sht" (shot, shut), "cnt" (count), "dck" (deck, duck)
+	m = d().WithConsonantSkeletons(3).ScanAllProfanities("x sht cnt dck")
+	assert.Nil(t, m)
+	m = NewProfanityDetector().WithProfaneWords([]string{"shit"}).
+		WithConsonantSkeletons(3).ScanAllProfanities("x sht")
+	assert.Equal(t, "shit", m[0].Word)
+	m = NewProfanityDetector().WithProfaneWords([]string{"shit"}).WithFalsePositiveWords([]string{"shot"}).
+		WithConsonantSkeletons(3).ScanAllProfanities("x sht")
+	assert.Nil(t, m)
+
+	// Short skeletons are skipped
+	m = d().WithConsonantSkeletons(3).ScanAllProfanities("x sx")
+	assert.Nil(t, m)
+	m = d().WithConsonantSkeletons(4).ScanAllProfanities("x prn")
+	assert.Nil(t, m)
+
+	m = d().ScanAllProfanities("x prn")
+	assert.Nil(t, m)
+}
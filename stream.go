@@ -0,0 +1,255 @@
+package profanityout
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	streamReadSize = 4096
+	// Matching text can be longer than its dictionary word because of sanitization of spaces,
+	// special characters and repeated characters, the lookback is multiplied by this factor
+	streamLookbackFactor = 4
+	streamMinLookback    = 64
+	// Number of characters kept before the scanning position to determine word boundaries
+	streamContextLen = 8
+)
+
+// streamScanner scans text chunk by chunk. It only keeps the characters which may be part of
+// a match not decided yet, so the memory usage is bounded no matter how large the input is.
+type streamScanner struct {
+	detector *ProfanityDetector
+	options  []DetectorOption
+	lookback int
+
+	buf     []rune       // pending characters normalized as NFC, buf[:resume] are only used as context
+	text    []byte       // data which buf is normalized from
+	offsets []textOffset // offsets of the characters of buf in text, the end of text is the last item
+	resume  int          // position in buf to continue scanning from
+	base    offsetCursor // offsets of buf[0] in the stream
+	pending []byte       // data not normalized yet: the last character with its combining marks
+	// skipping tells whether the word at the resume position is being skipped and prevCh is
+	// the character scanned before the resume position (0 when it's the character before it)
+	skipping bool
+	prevCh   rune
+
+	handler func(*Match) bool
+	stopped bool
+
+	// output receives the text which is final with all profanities censored, in order.
+	// When this is nil, censoring is not performed.
+	output func(string)
+}
+
+func (d *ProfanityDetector) newStreamScanner(handler func(*Match) bool, options ...DetectorOption) *streamScanner {
+	settings := d.settings
+	for _, opt := range options {
+		opt(&settings)
+	}
+	lookback := settings.StreamLookback
+	if lookback <= 0 {
+		maxWordLen := d.profanityTree.maxWordLen
+		if d.falsePositiveTree.maxWordLen > maxWordLen {
+			maxWordLen = d.falsePositiveTree.maxWordLen
+		}
+		lookback = maxWordLen * streamLookbackFactor
+		if lookback < streamMinLookback {
+			lookback = streamMinLookback
+		}
+	}
+	return &streamScanner{
+		detector: d,
+		options:  options,
+		lookback: lookback,
+		offsets:  []textOffset{{}},
+		handler:  handler,
+	}
+}
+
+// write appends a chunk of data and scans the part which is far enough from the end of the data
+func (s *streamScanner) write(p []byte) {
+	if s.stopped {
+		return
+	}
+	// The last character and its combining marks are normalized with the data coming later
+	s.pending = append(s.pending, p...)
+	complete := len(s.pending) - incompleteSuffixLen(s.pending)
+	boundary := norm.NFC.LastBoundary(s.pending[:complete])
+	if boundary < 0 || complete-boundary > norm.MaxSegmentSize {
+		boundary = complete
+	}
+	s.appendText(s.pending[:boundary])
+	s.pending = append(s.pending[:0], s.pending[boundary:]...)
+
+	// Characters before the lookback area are decided
+	if len(s.buf)-s.lookback > s.resume {
+		s.process(false)
+	}
+}
+
+// close scans all the remaining data
+func (s *streamScanner) close() {
+	if s.stopped {
+		return
+	}
+	s.appendText(s.pending) // invalid bytes are decoded as utf8.RuneError
+	s.pending = nil
+	s.process(true)
+}
+
+// appendText normalizes the data and appends it to the pending characters
+func (s *streamScanner) appendText(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	normalized, offsets := normalizeAsNFCWithOffsets(string(data))
+	if offsets == nil {
+		offsets = make([]textOffset, 0, len(normalized)+1)
+		offset := textOffset{}
+		for i, ch := range normalized { // invalid bytes are decoded one by one
+			offsets = append(offsets, textOffset{byteOffset: i, utf16Offset: offset.utf16Offset})
+			offset.utf16Offset += utf16Len(ch)
+		}
+		offsets = append(offsets, textOffset{byteOffset: len(normalized), utf16Offset: offset.utf16Offset})
+	}
+	end := s.offsets[len(s.offsets)-1]
+	s.offsets = s.offsets[:len(s.offsets)-1]
+	for _, offset := range offsets {
+		s.offsets = append(s.offsets, textOffset{byteOffset: end.byteOffset + offset.byteOffset,
+			utf16Offset: end.utf16Offset + offset.utf16Offset})
+	}
+	s.buf = append(s.buf, []rune(normalized)...)
+	s.text = append(s.text, data...)
+}
+
+// incompleteSuffixLen returns the length of the incomplete UTF-8 sequence at the end of the data
+func incompleteSuffixLen(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if utf8.FullRune(data[i:]) {
+				return 0
+			}
+			return len(data) - i
+		}
+	}
+	return 0
+}
+
+// process scans the pending characters up to the lookback area and reports the matches found,
+// those matches can't be affected by data coming later. Scanning stops at the position where
+// scanning the whole text would continue from, so the next call resumes from there.
+func (s *streamScanner) process(final bool) {
+	scanner := s.detector.newScanner(true, s.options...)
+	scanner.startPos, scanner.startPrevCh, scanner.skipWord = s.resume, s.prevCh, s.skipping
+	if !final {
+		scanner.endPos = len(s.buf) - s.lookback
+	}
+	matches := scanner.scanNormalized(string(s.text), s.buf, s.offsets)
+
+	// All characters before `next` are decided
+	next := minInt(scanner.stopPos, len(s.buf))
+	if final {
+		next = len(s.buf)
+	}
+	for _, match := range matches {
+		if match.Start < next && match.End > next {
+			next = match.End
+		}
+	}
+	for next > 0 && next < len(s.buf) && s.offsets[next] == s.offsets[next-1] {
+		next++ // characters normalized from the same data are decided together
+	}
+
+	var content []rune
+	if s.output != nil {
+		content = append([]rune{}, s.buf...)
+	}
+	for _, match := range matches {
+		if match.Start >= next {
+			break
+		}
+		if content != nil && match.IsProfane() {
+			scanner.censorMatch(content, match)
+		}
+		s.base.shift(match)
+		match.Text = append([]rune{}, match.Text...)
+		if s.handler != nil && !s.handler(match) {
+			s.stopped = true
+			return
+		}
+	}
+	if content != nil {
+		output := scanner.censoredText(content, s.offsets[s.resume].byteOffset, s.offsets[next].byteOffset)
+		if output != "" {
+			s.output(output)
+		}
+	}
+	if final {
+		s.discard(len(s.buf))
+		return
+	}
+
+	// Discards the characters which are not needed anymore
+	s.skipping = scanner.stopPos >= len(s.buf)
+	s.prevCh = 0
+	if next == scanner.stopPos {
+		s.prevCh = scanner.stopPrevCh
+	}
+	keep := next - streamContextLen
+	if keep < 0 {
+		keep = 0
+	}
+	for keep > 0 && s.offsets[keep] == s.offsets[keep-1] {
+		keep-- // characters normalized from the same data are kept together
+	}
+	s.discard(keep)
+	s.resume = next - keep
+}
+
+// discard removes the first n pending characters and the data they are normalized from
+func (s *streamScanner) discard(n int) {
+	start := s.offsets[n]
+	s.base.pos += n
+	s.base.byteOffset += start.byteOffset
+	s.base.utf16Offset += start.utf16Offset
+	s.buf = append(s.buf[:0], s.buf[n:]...)
+	s.text = append(s.text[:0], s.text[start.byteOffset:]...)
+	offsets := s.offsets[:0]
+	for _, offset := range s.offsets[n:] {
+		offsets = append(offsets, textOffset{byteOffset: offset.byteOffset - start.byteOffset,
+			utf16Offset: offset.utf16Offset - start.utf16Offset})
+	}
+	s.offsets = offsets
+}
+
+// shift converts the positions of the match in the buffer into positions in the stream
+func (c *offsetCursor) shift(match *Match) {
+	match.Start += c.pos
+	match.End += c.pos
+	match.ByteStart += c.byteOffset
+	match.ByteEnd += c.byteOffset
+	match.UTF16Start += c.utf16Offset
+	match.UTF16End += c.utf16Offset
+}
+
+// readAll reads data from the reader and writes to the stream scanner until EOF
+func (s *streamScanner) readAll(r io.Reader) error {
+	buf := make([]byte, streamReadSize)
+	for !s.stopped {
+		n, err := r.Read(buf)
+		if n > 0 {
+			s.write(buf[:n])
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+	}
+	s.close()
+	return nil
+}
@@ -0,0 +1,125 @@
+package profanityout
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func scanReaderAll(t *testing.T, d *ProfanityDetector, s string, options ...DetectorOption) (matches Matches) {
+	err := d.ScanReader(iotest.OneByteReader(strings.NewReader(s)), func(m *Match) bool {
+		matches = append(matches, m)
+		return true
+	}, options...)
+	assert.Nil(t, err)
+	return matches
+}
+
+func Test_ScanReader(t *testing.T) {
+	d := newDetectorEN
+
+	t.Run("Same result as scanning the whole text", func(t *testing.T) {
+		text := strings.Repeat("hello wörld, fuck this $h!!t and f u c k 😀 analytics bada$$ a $ $ x ", 200)
+		expected := d().ScanAllProfanities(text)
+		actual := scanReaderAll(t, d(), text, WithStreamLookback(20))
+		assert.Equal(t, len(expected), len(actual))
+		for i := range expected {
+			assert.Equal(t, expected[i].Start, actual[i].Start)
+			assert.Equal(t, expected[i].End, actual[i].End)
+			assert.Equal(t, expected[i].Word, actual[i].Word)
+			assert.Equal(t, expected[i].WordType, actual[i].WordType)
+			assert.Equal(t, string(expected[i].Text), string(actual[i].Text))
+			assert.Equal(t, text[expected[i].ByteStart:expected[i].ByteEnd], text[actual[i].ByteStart:actual[i].ByteEnd])
+			assert.Equal(t, expected[i].UTF16Start, actual[i].UTF16Start)
+		}
+	})
+
+	t.Run("Match crossing chunk boundaries", func(t *testing.T) {
+		text := strings.Repeat("x", 5000) + " fuuuuuuuuuuuuck"
+		m := scanReaderAll(t, d(), text)
+		assert.Equal(t, 1, len(m))
+		assert.Equal(t, &Match{Word: "fuck", Start: 5001, End: 5016, WordType: WordTypeProfanity,
+			Text: []rune("fuuuuuuuuuuuuck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	})
+
+	t.Run("Invalid UTF-8 bytes", func(t *testing.T) {
+		text := "x \xff\xfe fuck, h\xc3llo sh\xe2\x82it fúck \xf0\x9f"
+		expected := d().ScanAllProfanities(text)
+		actual := scanReaderAll(t, d(), text)
+		assert.Equal(t, 2, len(actual))
+		for i := range expected {
+			assert.Equal(t, toCmp(expected[i]), toCmp(actual[i]))
+			assert.Equal(t, []int{expected[i].ByteStart, expected[i].ByteEnd, expected[i].UTF16Start},
+				[]int{actual[i].ByteStart, actual[i].ByteEnd, actual[i].UTF16Start})
+		}
+		assert.Equal(t, "fuck", text[actual[0].ByteStart:actual[0].ByteEnd])
+		assert.Equal(t, "fúck", text[actual[1].ByteStart:actual[1].ByteEnd])
+	})
+
+	t.Run("Stop scanning", func(t *testing.T) {
+		count := 0
+		err := d().ScanReader(strings.NewReader(strings.Repeat("fuck ", 10000)), func(m *Match) bool {
+			count++
+			return count < 3
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Reader error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		err := d().ScanReader(iotest.ErrReader(errTest), func(m *Match) bool { return true })
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func Test_ScanReader_Random(t *testing.T) {
+	d := newDetectorEN()
+	words := append(append([]string{"f u c k", "$h!t", "a$$", "fuuuck", "sh1t", "-", ",", "...", "😀", "  ", "x",
+		"héllo", "he\u0301llo", "fu\u0301ck", "s-h-i-t"}, en.DefaultProfanities...), en.DefaultFalsePositives...)
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec
+	for i := 0; i < 50; i++ {
+		var sb strings.Builder
+		for sb.Len() < 12000 {
+			sb.WriteString(words[rnd.Intn(len(words))])
+			if rnd.Intn(4) > 0 {
+				sb.WriteByte(' ')
+			}
+		}
+		text := sb.String()
+		expected := d.ScanAllProfanities(text)
+		for _, r := range []io.Reader{strings.NewReader(text), iotest.OneByteReader(strings.NewReader(text))} {
+			var actual Matches
+			err := d.ScanReader(r, func(m *Match) bool {
+				actual = append(actual, m)
+				return true
+			})
+			assert.Nil(t, err)
+			if !assert.Equal(t, len(expected), len(actual)) {
+				return
+			}
+			for j := range expected {
+				assert.Equal(t, toCmp(expected[j]), toCmp(actual[j]))
+				assert.Equal(t, expected[j].ByteStart, actual[j].ByteStart)
+			}
+		}
+	}
+}
+
+func Test_streamScanner_BoundedMemory(t *testing.T) {
+	d := newDetectorEN()
+	s := d.newStreamScanner(func(*Match) bool { return true })
+	chunk := []byte(strings.Repeat("lorem ipsum shit ", 100))
+	for i := 0; i < 1000; i++ {
+		s.write(chunk)
+		assert.LessOrEqual(t, len(s.buf), len(chunk)+s.lookback+streamContextLen)
+	}
+	s.close()
+}
@@ -0,0 +1,229 @@
+package profanityout
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	structTagName = "profanity"
+)
+
+var (
+	ErrInvalidStructTag = errors.New("profanityout: invalid struct tag")
+	ErrNotPointer       = errors.New("profanityout: value must be a non-nil pointer")
+)
+
+// structFieldMode is the mode of a struct field set by the tag `profanity:"..."`
+type structFieldMode int8
+
+const (
+	structFieldNone     structFieldMode = iota // strings are not scanned
+	structFieldScan                            // "scan": strings are scanned
+	structFieldCensor                          // "censor": strings are scanned and censored by CensorStruct
+	structFieldUsername                        // "username": strings are validated as usernames
+	structFieldSkip                            // "-": the field is skipped with all values inside it
+)
+
+func parseStructFieldMode(tag string) (structFieldMode, error) {
+	switch strings.TrimSpace(tag) {
+	case "":
+		return structFieldNone, nil
+	case "scan":
+		return structFieldScan, nil
+	case "censor":
+		return structFieldCensor, nil
+	case "username":
+		return structFieldUsername, nil
+	case "-":
+		return structFieldSkip, nil
+	}
+	return structFieldNone, fmt.Errorf("%w: %q", ErrInvalidStructTag, tag)
+}
+
+// structWalker walks a value with reflection and scans the strings according to the struct tags
+type structWalker struct {
+	detector *ProfanityDetector
+	options  []DetectorOption
+	scanner  *scanner
+	censor   bool
+	matches  map[string]Matches
+	visited  map[uintptr]struct{}
+}
+
+// ScanStruct scans the string fields of the struct tagged with `profanity:"scan"`, `profanity:"censor"`
+// or `profanity:"username"` (validated as usernames with ValidateUsername). Nested structs, pointers,
+// slices, arrays, maps and interfaces are walked, a tag on them applies to all strings inside them
+// unless a nested field has its own tag. Fields tagged with `profanity:"-"` are skipped.
+//
+// Matches are keyed by field paths such as "Author.Name", "Comments[0].Text" or "Meta[key]".
+func (d *ProfanityDetector) ScanStruct(v any, options ...DetectorOption) (map[string]Matches, error) {
+	walker := d.newStructWalker(false, options...)
+	if _, _, err := walker.walk(reflect.ValueOf(v), "", structFieldNone); err != nil {
+		return nil, err
+	}
+	return walker.matches, nil
+}
+
+// CensorStruct scans the struct the same way as ScanStruct and censors the strings of the fields
+// tagged with `profanity:"censor"` in place. The value must be a pointer.
+func (d *ProfanityDetector) CensorStruct(v any, options ...DetectorOption) (map[string]Matches, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil, ErrNotPointer
+	}
+	walker := d.newStructWalker(true, options...)
+	if _, _, err := walker.walk(value, "", structFieldNone); err != nil {
+		return nil, err
+	}
+	return walker.matches, nil
+}
+
+func (d *ProfanityDetector) newStructWalker(censor bool, options ...DetectorOption) *structWalker {
+	return &structWalker{
+		detector: d,
+		options:  options,
+		scanner:  d.newScanner(true, options...),
+		censor:   censor,
+		matches:  map[string]Matches{},
+		visited:  map[uintptr]struct{}{},
+	}
+}
+
+// walk scans the strings in the value. When a string is censored and the value can't be set
+// (map values, interfaces), the censored value is returned to be set by the caller.
+//
+//nolint:gocognit,gocyclo
+func (w *structWalker) walk(v reflect.Value, path string, mode structFieldMode) (reflect.Value, bool, error) {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		censored, changed := w.scanString(v.String(), path, mode)
+		if !changed {
+			return reflect.Value{}, false, nil
+		}
+		if v.CanSet() {
+			v.SetString(censored)
+			return reflect.Value{}, false, nil
+		}
+		return reflect.ValueOf(censored).Convert(v.Type()), true, nil
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Value{}, false, nil
+		}
+		if _, exists := w.visited[v.Pointer()]; exists {
+			return reflect.Value{}, false, nil // cyclic reference
+		}
+		w.visited[v.Pointer()] = struct{}{}
+		defer delete(w.visited, v.Pointer())
+		_, _, err := w.walk(v.Elem(), path, mode)
+		return reflect.Value{}, false, err
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Value{}, false, nil
+		}
+		replacement, changed, err := w.walk(v.Elem(), path, mode)
+		if err != nil || !changed {
+			return reflect.Value{}, false, err
+		}
+		if v.CanSet() {
+			v.Set(replacement)
+			return reflect.Value{}, false, nil
+		}
+		return replacement, true, nil
+
+	case reflect.Struct:
+		// Fields of an unaddressable struct (a map value) can't be set, a copy is walked and returned
+		copied := false
+		if !v.CanAddr() && w.censor {
+			addressable := reflect.New(v.Type()).Elem()
+			addressable.Set(v)
+			v, copied = addressable, true
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+				continue // exported fields of embedded structs are accessible
+			}
+			fieldMode, err := parseStructFieldMode(field.Tag.Get(structTagName))
+			if err != nil {
+				return reflect.Value{}, false, fmt.Errorf("%w (field %s)", err, joinStructPath(path, field.Name))
+			}
+			if fieldMode == structFieldSkip {
+				continue
+			}
+			if fieldMode == structFieldNone {
+				fieldMode = mode
+			}
+			fieldPath := path // fields of embedded structs are promoted
+			if !field.Anonymous {
+				fieldPath = joinStructPath(path, field.Name)
+			}
+			replacement, fieldChanged, err := w.walk(v.Field(i), fieldPath, fieldMode)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			if fieldChanged && v.Field(i).CanSet() {
+				v.Field(i).Set(replacement)
+			}
+		}
+		return v, copied, nil
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			replacement, changed, err := w.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), mode)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			if changed && v.Index(i).CanSet() {
+				v.Index(i).Set(replacement)
+			}
+		}
+		return reflect.Value{}, false, nil
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			replacement, changed, err := w.walk(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), mode)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			if changed {
+				v.SetMapIndex(iter.Key(), replacement)
+			}
+		}
+		return reflect.Value{}, false, nil
+	}
+	return reflect.Value{}, false, nil
+}
+
+// scanString scans the string of the field, the censored string is returned when it's changed
+func (w *structWalker) scanString(s, path string, mode structFieldMode) (string, bool) {
+	var matches Matches
+	switch mode { //nolint:exhaustive
+	case structFieldScan, structFieldCensor:
+		matches = w.scanner.scan(s)
+	case structFieldUsername:
+		matches = w.detector.ValidateUsername(s, w.options...).Matches
+	default:
+		return s, false
+	}
+	if len(matches) == 0 {
+		return s, false
+	}
+	w.matches[path] = append(w.matches[path], matches...)
+	if !w.censor || mode != structFieldCensor || !matches.HasProfaneMatch() {
+		return s, false
+	}
+	return w.scanner.censorInput(matches), true
+}
+
+func joinStructPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
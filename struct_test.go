@@ -0,0 +1,104 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStructMeta struct {
+	Note string `profanity:"censor"`
+}
+
+type testStructComment struct {
+	Text string `profanity:"censor"`
+	ID   string
+}
+
+type testStructPost struct {
+	testStructMeta
+	Title    string `profanity:"censor"`
+	Summary  string `profanity:"scan"`
+	Author   string `profanity:"username"`
+	Secret   string `profanity:"-"`
+	Raw      string
+	Tags     []string          `profanity:"censor"`
+	Extra    map[string]string `profanity:"censor"`
+	Any      any               `profanity:"censor"`
+	Comments []testStructComment
+	Pinned   *testStructComment
+	Items    map[string]testStructComment
+	Skipped  *testStructComment `profanity:"-"`
+	Parent   *testStructPost
+}
+
+func newTestStructPost() *testStructPost {
+	post := &testStructPost{
+		testStructMeta: testStructMeta{Note: "fuck"},
+		Title:          "fuck this",
+		Summary:        "shit",
+		Author:         "xXfuckerXx",
+		Secret:         "shit",
+		Raw:            "shit",
+		Tags:           []string{"ok", "shit"},
+		Extra:          map[string]string{"k": "cunt"},
+		Any:            "fuck",
+		Comments:       []testStructComment{{Text: "ok"}, {Text: "cunt", ID: "fuck"}},
+		Pinned:         &testStructComment{Text: "shit"},
+		Items:          map[string]testStructComment{"a": {Text: "fuck"}},
+		Skipped:        &testStructComment{Text: "shit"},
+	}
+	post.Parent = post
+	return post
+}
+
+func Test_ScanStruct(t *testing.T) {
+	d := newDetectorEN()
+
+	post := newTestStructPost()
+	m, err := d.ScanStruct(post)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(m))
+	for _, path := range []string{"Note", "Title", "Summary", "Author", "Tags[1]", "Extra[k]", "Any",
+		"Comments[1].Text", "Pinned.Text", "Items[a].Text"} {
+		assert.True(t, m[path].HasProfaneMatch(), path)
+	}
+	assert.Equal(t, "fuck", m["Author"][0].Word)
+	assert.Equal(t, "fuck this", post.Title)
+
+	// Values are accepted as well
+	m, err = d.ScanStruct(testStructComment{Text: "shit"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(m["Text"]))
+
+	_, err = d.ScanStruct(struct {
+		Text string `profanity:"x"`
+	}{})
+	assert.ErrorIs(t, err, ErrInvalidStructTag)
+}
+
+func Test_CensorStruct(t *testing.T) {
+	d := newDetectorEN()
+
+	post := newTestStructPost()
+	_, err := d.CensorStruct(post)
+	assert.Nil(t, err)
+	assert.Equal(t, "****", post.Note)
+	assert.Equal(t, "**** this", post.Title)
+	assert.Equal(t, "shit", post.Summary)
+	assert.Equal(t, "xXfuckerXx", post.Author)
+	assert.Equal(t, "shit", post.Secret)
+	assert.Equal(t, "shit", post.Raw)
+	assert.Equal(t, []string{"ok", "****"}, post.Tags)
+	assert.Equal(t, map[string]string{"k": "****"}, post.Extra)
+	assert.Equal(t, "****", post.Any)
+	assert.Equal(t, []testStructComment{{Text: "ok"}, {Text: "****", ID: "fuck"}}, post.Comments)
+	assert.Equal(t, "****", post.Pinned.Text)
+	assert.Equal(t, "****", post.Items["a"].Text)
+	assert.Equal(t, "shit", post.Skipped.Text)
+
+	_, err = d.CensorStruct(testStructComment{})
+	assert.ErrorIs(t, err, ErrNotPointer)
+	_, err = d.CensorStruct((*testStructComment)(nil))
+	assert.ErrorIs(t, err, ErrNotPointer)
+}
@@ -0,0 +1,230 @@
+package profanityout
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// URLPolicy defines how URLs and email addresses found in the input are scanned
+type URLPolicy int8
+
+const (
+	// URLPolicyScan scans URLs and email addresses the same way as normal text (default)
+	URLPolicyScan URLPolicy = iota
+	// URLPolicySkip doesn't scan URLs and email addresses at all
+	URLPolicySkip
+	// URLPolicyHostAndPath only scans the host and the path of URLs and the address of emails.
+	// Schemes, user info, ports, query strings and fragments are skipped.
+	URLPolicyHostAndPath
+)
+
+// urlSeparators separate words in hosts, paths and email addresses
+const urlSeparators = "./-_+~=&;,:@"
+
+// urlPart is a part of a URL or an email address found in the input
+type urlPart struct {
+	start, end int
+	scanned    bool // whether the part is scanned with URLPolicyHostAndPath
+}
+
+// findURLs finds URLs (with a scheme or starting with "www.") and email addresses in the input
+// and returns their parts
+func findURLs(input []rune) (parts []urlPart) {
+	for i := 0; i < len(input); {
+		if unicode.IsSpace(input[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(input) && !unicode.IsSpace(input[i]) {
+			i++
+		}
+		// Trims the punctuation around the token
+		tokenStart, tokenEnd := start, i
+		for tokenStart < tokenEnd && strings.ContainsRune(`("'<[`, input[tokenStart]) {
+			tokenStart++
+		}
+		for tokenEnd > tokenStart && strings.ContainsRune(`.,;:!?)"'>]`, input[tokenEnd-1]) {
+			tokenEnd--
+		}
+		if tokenStart == tokenEnd {
+			continue
+		}
+		if urlParts := splitURL(input, tokenStart, tokenEnd); urlParts != nil {
+			parts = append(parts, urlParts...)
+		} else if isEmailAddress(input[tokenStart:tokenEnd]) {
+			parts = append(parts, urlPart{start: tokenStart, end: tokenEnd, scanned: true})
+		}
+	}
+	return parts
+}
+
+// splitURL splits the URL input[start:end] into parts, nil is returned when it's not a URL
+func splitURL(input []rune, start, end int) []urlPart {
+	token := input[start:end]
+	hostStart := 0
+	if schemeEnd := indexRunes(token, "://"); schemeEnd > 0 && isURLScheme(token[:schemeEnd]) {
+		hostStart = schemeEnd + len("://")
+	} else if !hasPrefixFold(token, "www.") {
+		return nil
+	}
+
+	// Authority ends at the first "/", "?" or "#"
+	pathStart := hostStart
+	for pathStart < len(token) && !strings.ContainsRune("/?#", token[pathStart]) {
+		pathStart++
+	}
+	if pathStart == hostStart {
+		return nil
+	}
+	hostEnd := pathStart
+	for i := hostStart; i < pathStart; i++ {
+		switch token[i] {
+		case '@': // user info
+			hostStart, hostEnd = i+1, pathStart
+		case ':': // port
+			hostEnd = i
+		}
+	}
+	pathEnd := pathStart
+	for pathEnd < len(token) && token[pathEnd] != '?' && token[pathEnd] != '#' {
+		pathEnd++
+	}
+
+	parts := make([]urlPart, 0, 5) //nolint:mnd
+	for _, part := range []urlPart{
+		{start: start, end: start + hostStart},
+		{start: start + hostStart, end: start + hostEnd, scanned: true},
+		{start: start + hostEnd, end: start + pathStart},
+		{start: start + pathStart, end: start + pathEnd, scanned: true},
+		{start: start + pathEnd, end: end},
+	} {
+		if part.start < part.end {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func isURLScheme(scheme []rune) bool {
+	for i, ch := range scheme {
+		if ch > unicode.MaxASCII || !(unicode.IsLetter(ch) || (i > 0 && strings.ContainsRune("0123456789+-.", ch))) {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmailAddress(token []rune) bool {
+	at := -1
+	for i, ch := range token {
+		if ch == '@' {
+			if at >= 0 {
+				return false
+			}
+			at = i
+			continue
+		}
+		if unicode.IsSpace(ch) || strings.ContainsRune(`"(),:;<>[\]`, ch) {
+			return false
+		}
+	}
+	if at <= 0 || at == len(token)-1 {
+		return false
+	}
+	domain := token[at+1:]
+	dot := indexRunes(domain, ".")
+	return dot > 0 && dot < len(domain)-1
+}
+
+// indexRunes returns the rune index of the first instance of sub in s, or -1 if sub is not present
+func indexRunes(s []rune, sub string) int {
+	subRunes := []rune(sub)
+	for i := 0; i+len(subRunes) <= len(s); i++ {
+		if string(s[i:i+len(subRunes)]) == sub {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasPrefixFold(s []rune, prefix string) bool {
+	prefixRunes := []rune(prefix)
+	if len(s) < len(prefixRunes) {
+		return false
+	}
+	return strings.EqualFold(string(s[:len(prefixRunes)]), prefix)
+}
+
+// applyURLPolicy handles URLs and email addresses in the input according to the settings.
+// Skipped characters and separators of the scanned parts are replaced by spaces in the sanitized input,
+// so positions are not changed.
+func (s *scanner) applyURLPolicy() {
+	s.percentEncoded = nil
+	policy := s.settings.URLPolicy
+	if policy == URLPolicyScan && !s.settings.URLPercentDecode {
+		return
+	}
+	parts := findURLs(s.input)
+	if len(parts) == 0 {
+		return
+	}
+	if policy != URLPolicyScan && &s.input[0] == &s.inputOrig[0] {
+		s.input = append([]rune{}, s.input...) // the original input must be kept
+	}
+	if s.settings.URLPercentDecode {
+		s.percentEncoded = make([]bool, len(s.input))
+	}
+	for _, part := range parts {
+		skipped := policy == URLPolicySkip || (policy == URLPolicyHostAndPath && !part.scanned)
+		for i := part.start; i < part.end; i++ {
+			switch {
+			case skipped:
+				s.input[i] = ' '
+			case policy == URLPolicyHostAndPath && strings.ContainsRune(urlSeparators, s.input[i]):
+				s.input[i] = ' '
+			case s.percentEncoded != nil:
+				s.percentEncoded[i] = true
+			}
+		}
+	}
+}
+
+// decodePercentEncodingAt decodes a percent-encoded UTF-8 character ("%C3%BC") at the position
+func decodePercentEncodingAt(input []rune, i int) (rune, int) {
+	var buf [utf8.UTFMax]byte
+	n, next := 0, i
+	for n < len(buf) && next+2 < len(input) && input[next] == '%' {
+		hi, lo := unhex(input[next+1]), unhex(input[next+2])
+		if hi < 0 || lo < 0 {
+			break
+		}
+		buf[n] = byte(hi<<4 | lo) //nolint:mnd
+		n++
+		next += 3
+		if utf8.FullRune(buf[:n]) {
+			break
+		}
+	}
+	if n == 0 {
+		return input[i], i
+	}
+	ch, size := utf8.DecodeRune(buf[:n])
+	if ch == utf8.RuneError || size != n {
+		return input[i], i
+	}
+	return ch, next
+}
+
+func unhex(ch rune) int {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return int(ch - '0')
+	case ch >= 'a' && ch <= 'f':
+		return int(ch-'a') + 10 //nolint:mnd
+	case ch >= 'A' && ch <= 'F':
+		return int(ch-'A') + 10 //nolint:mnd
+	}
+	return -1
+}
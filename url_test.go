@@ -0,0 +1,72 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindURLs(t *testing.T) {
+	input := []rune("see (https://user@example.com:80/a/b?q=1#top), www.example.com and me@example.com.")
+	assert.Equal(t, []urlPart{
+		{start: 5, end: 18},
+		{start: 18, end: 29, scanned: true},
+		{start: 29, end: 32},
+		{start: 32, end: 36, scanned: true},
+		{start: 36, end: 44},
+		{start: 47, end: 62, scanned: true},
+		{start: 67, end: 81, scanned: true},
+	}, findURLs(input))
+
+	assert.Nil(t, findURLs([]rune("example.com is not a URL, @me is not an email")))
+}
+
+func Test_DecodePercentEncodingAt(t *testing.T) {
+	ch, next := decodePercentEncodingAt([]rune("%66uck"), 0)
+	assert.Equal(t, 'f', ch)
+	assert.Equal(t, 3, next)
+
+	ch, next = decodePercentEncodingAt([]rune("%C3%BCck"), 0)
+	assert.Equal(t, 'ü', ch)
+	assert.Equal(t, 6, next)
+
+	_, next = decodePercentEncodingAt([]rune("%6"), 0)
+	assert.Equal(t, 0, next)
+	_, next = decodePercentEncodingAt([]rune("%C3x"), 0)
+	assert.Equal(t, 0, next)
+}
+
+func Test_Scan_URLPolicy(t *testing.T) {
+	d := newDetectorEN
+	text := "see https://fuck.example.com/shit?ref=cunt ok"
+	var m Matches
+
+	m = d().WithURLPolicy(URLPolicyHostAndPath).ScanAllProfanities(text)
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, &Match{Word: "fuck", Start: 12, End: 16, WordType: WordTypeProfanity,
+		Text: []rune("fuck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, []rune("shit"), m[1].Text)
+
+	m = d().ScanAllProfanities(text, WithURLPolicy(URLPolicySkip))
+	assert.Nil(t, m)
+	m = d().ScanAllProfanities("fuck https://example.com", WithURLPolicy(URLPolicySkip))
+	assert.Equal(t, []rune("fuck"), m[0].Text)
+
+	m = d().ScanAllProfanities("mail to fuck@example.com", WithURLPolicy(URLPolicyHostAndPath))
+	assert.Equal(t, 8, m[0].Start)
+	m = d().ScanAllProfanities("mail to fuck@example.com", WithURLPolicy(URLPolicySkip))
+	assert.Nil(t, m)
+
+	// Percent-encoded characters are decoded, positions refer to the original text
+	m = d().WithURLPolicy(URLPolicyHostAndPath).WithURLPercentDecode(true).
+		ScanAllProfanities("é https://example.com/f%C3%BCck")
+	assert.Equal(t, &Match{Word: "fuck", Start: 22, End: 31, WordType: WordTypeProfanity,
+		Text: []rune("f%C3%BCck"), HeadSpace: true, TailSpace: true}, toCmp(m[0]))
+	assert.Equal(t, 23, m[0].ByteStart)
+	m = d().ScanAllProfanities("https://example.com/f%75ck", WithURLPolicy(URLPolicyHostAndPath))
+	assert.Nil(t, m)
+
+	// Censoring keeps the URL structure
+	censored, _ := d().WithURLPolicy(URLPolicyHostAndPath).Censor(text)
+	assert.Equal(t, "see https://****.example.com/****?ref=cunt ok", censored)
+}
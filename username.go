@@ -0,0 +1,78 @@
+package profanityout
+
+import (
+	"fmt"
+)
+
+// UsernameReasonCode identifies why a username is reported
+type UsernameReasonCode string
+
+const (
+	UsernameReasonProfanity UsernameReasonCode = "profanity"
+	UsernameReasonSuspect   UsernameReasonCode = "suspect"
+)
+
+// UsernameReason describes a problem found in a username
+type UsernameReason struct {
+	Code    UsernameReasonCode
+	Message string
+	Match   *Match
+}
+
+// UsernameVerdict is the result of username validation. A username is valid when it doesn't
+// contain any profanity, suspect words are reported as reasons without invalidating it.
+type UsernameVerdict struct {
+	Valid   bool
+	Reasons []UsernameReason
+	Matches Matches
+}
+
+// usernameProfile contains the settings for usernames and handles. They don't contain spaces
+// and words are often joined or decorated, so dictionary words are matched anywhere in the username
+// and all sanitization of obfuscation is enabled.
+var usernameProfile = []DetectorOption{
+	WithMatchEmbeddedWords(true),
+	WithSanitizeLeetSpeak(true),
+	WithSanitizeRepeatedLeetSpeak(true),
+	WithSanitizeSpecialCharacters(true),
+	WithSanitizeRepeatedCharacters(true),
+	WithSanitizeWildcardCharacters(true),
+	WithSanitizeAccents(true),
+	WithSanitizeEmoji(true),
+	WithProcessInputAsHTML(false),
+}
+
+// ValidateUsername checks a username or handle for profanities. The whole username is treated as a single
+// token and dictionary words are searched anywhere inside it (e.g. "xXfuckerXx", "big_a55_dog").
+// The false positives set via WithUsernameFalsePositiveWords are used in addition to the normal ones.
+// The username profile is applied on top of the detector settings, followed by the options set via
+// WithUsernameOptions and the given options.
+func (d *ProfanityDetector) ValidateUsername(username string, options ...DetectorOption) *UsernameVerdict {
+	opts := make([]DetectorOption, 0, len(usernameProfile)+len(d.usernameOptions)+len(options))
+	opts = append(opts, usernameProfile...)
+	opts = append(opts, d.usernameOptions...)
+	opts = append(opts, options...)
+
+	scanner := d.newScanner(true, opts...)
+	scanner.falsePositiveTree = d.usernameFalsePositiveTree
+	matches := scanner.scan(username) // leading and trailing spaces are skipped as whitespace
+	verdict := &UsernameVerdict{Valid: true, Matches: matches}
+	for _, match := range matches {
+		switch match.WordType {
+		case WordTypeProfanity:
+			verdict.Valid = false
+			verdict.Reasons = append(verdict.Reasons, UsernameReason{
+				Code:    UsernameReasonProfanity,
+				Message: fmt.Sprintf("contains profane word %q as %q", match.Word, string(match.Text)),
+				Match:   match,
+			})
+		case WordTypeSuspect:
+			verdict.Reasons = append(verdict.Reasons, UsernameReason{
+				Code:    UsernameReasonSuspect,
+				Message: fmt.Sprintf("contains suspect word %q as %q", match.Word, string(match.Text)),
+				Match:   match,
+			})
+		}
+	}
+	return verdict
+}
@@ -0,0 +1,69 @@
+package profanityout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-profanity-out/data/en"
+)
+
+func Test_ValidateUsername(t *testing.T) {
+	d := func() *ProfanityDetector {
+		return newDetectorEN().WithUsernameFalsePositiveWords(en.UsernameFalsePositives)
+	}
+
+	v := d().ValidateUsername("xXfuckerXx")
+	assert.False(t, v.Valid)
+	assert.Equal(t, 1, len(v.Reasons))
+	assert.Equal(t, UsernameReasonProfanity, v.Reasons[0].Code)
+	assert.Equal(t, `contains profane word "fuck" as "fuck"`, v.Reasons[0].Message)
+	assert.Equal(t, &Match{Word: "fuck", Start: 2, End: 6, WordType: WordTypeProfanity,
+		Text: []rune("fuck"), HeadSpace: false, TailSpace: false}, toCmp(v.Reasons[0].Match))
+
+	v = d().ValidateUsername(" big_a55_dog ")
+	assert.False(t, v.Valid)
+	assert.Equal(t, []rune("a55"), v.Reasons[0].Match.Text)
+	// Offsets refer to the username as it is given
+	assert.Equal(t, []int{5, 8, 5, 8}, []int{v.Reasons[0].Match.Start, v.Reasons[0].Match.End,
+		v.Reasons[0].Match.ByteStart, v.Reasons[0].Match.ByteEnd})
+
+	v = d().ValidateUsername("sh1thead")
+	assert.False(t, v.Valid)
+	assert.Equal(t, "shit", v.Matches.GetFirstProfaneMatch().Word)
+
+	// False positives
+	for _, username := range []string{"john.smith", "classic_gamer", "peacock", "assemble", "scunthorpe_fc"} {
+		v = d().ValidateUsername(username)
+		assert.True(t, v.Valid, username)
+		assert.Nil(t, v.Reasons, username)
+	}
+	// Username false positives are not used when scanning text
+	assert.True(t, d().WithMatchEmbeddedWords(true).IsProfane("peacock"))
+
+	// Suspect words are reported without invalidating the username
+	v = d().WithSuspectWords([]string{"kill"}).ValidateUsername("killer99")
+	assert.True(t, v.Valid)
+	assert.Equal(t, UsernameReasonSuspect, v.Reasons[0].Code)
+
+	// Options
+	v = d().WithUsernameOptions(WithSanitizeLeetSpeak(false)).ValidateUsername("sh1thead")
+	assert.True(t, v.Valid)
+	v = d().ValidateUsername("sh1thead", WithSanitizeLeetSpeak(false))
+	assert.True(t, v.Valid)
+}
+
+func Test_Scan_MatchEmbeddedWords(t *testing.T) {
+	d := newDetectorEN
+
+	m := d().WithMatchEmbeddedWords(true).ScanAllProfanities("xfuckx")
+	assert.Equal(t, &Match{Word: "fuck", Start: 1, End: 5, WordType: WordTypeProfanity,
+		Text: []rune("fuck"), HeadSpace: false, TailSpace: false}, toCmp(m[0]))
+
+	// False positives still take precedence
+	m = d().ScanAllProfanities("assassin", WithMatchEmbeddedWords(true))
+	assert.False(t, m.HasProfaneMatch())
+
+	m = d().ScanAllProfanities("xfuckx", WithMatchEmbeddedWords(false))
+	assert.Nil(t, m)
+}
@@ -1,7 +1,9 @@
 package profanityout
 
 import (
+	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/runes"
 	"golang.org/x/text/transform"
@@ -17,21 +19,51 @@ var (
 	removeAccentsTransformer = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
 )
 
-// removeAccents strips all accents from characters
+// removeAccents strips all accents from characters.
+// This keeps the number of characters unchanged, so the input should be normalized as NFC first.
+// Standalone combining marks are kept as they are, the scanner skips them (see isStrippedMark).
 func removeAccents(s string) string {
-	for _, character := range s {
+	for i, character := range s {
 		// If there's a character outside the range of supported runes, there might be some accented words
 		if character < firstRuneSupported || character > lastRuneSupported {
-			ss, _, err := transform.String(removeAccentsTransformer, s)
-			if err == nil {
-				s = ss
-			}
-			break
+			return s[:i] + removeAccentsByChar(s[i:])
 		}
 	}
 	return s
 }
 
+func removeAccentsByChar(s string) string {
+	buf := make([]rune, 0, len(s))
+	for _, character := range s {
+		if character >= firstRuneSupported && character <= lastRuneSupported {
+			buf = append(buf, character)
+			continue
+		}
+		ss, _, err := transform.String(removeAccentsTransformer, string(character))
+		if err != nil || utf8.RuneCountInString(ss) != 1 {
+			buf = append(buf, character)
+			continue
+		}
+		ch, _ := utf8.DecodeRuneInString(ss)
+		buf = append(buf, ch)
+	}
+	return string(buf)
+}
+
+// isStrippedMark checks if the character is a standalone combining mark ignored when accents are sanitized,
+// such as the strikethrough in "f̶u̶c̶k̶"
+func isStrippedMark(ch rune) bool {
+	return ch > lastRuneSupported && unicode.Is(unicode.Mn, ch) && !unicode.In(ch, noSpaceScripts...)
+}
+
+// skipStrippedMarks returns the position of the next character which is not a stripped mark
+func skipStrippedMarks(input []rune, i int) int {
+	for i < len(input) && isStrippedMark(input[i]) {
+		i++
+	}
+	return i
+}
+
 func normalizeAsNFC(s string) string {
 	for _, character := range s {
 		// If there's a character outside the range of supported runes, there might be some accented words
@@ -45,3 +77,68 @@ func normalizeAsNFC(s string) string {
 	}
 	return s
 }
+
+// textOffset is a position in a text in UTF-8 bytes and UTF-16 code units
+type textOffset struct {
+	byteOffset  int
+	utf16Offset int
+}
+
+// normalizeAsNFCWithOffsets normalizes the text as NFC and maps the characters of the result to their
+// offsets in the text, the offsets of the end of the text are the last item. Characters normalized from
+// the same segment of the text (a character and its combining marks) share the offsets of the segment.
+// The offsets are nil when the text is already normalized.
+func normalizeAsNFCWithOffsets(text string) (string, []textOffset) {
+	if normalizeAsNFC(text) == text {
+		return text, nil
+	}
+	var normalized strings.Builder
+	offsets := make([]textOffset, 0, len(text)+1)
+	offset := textOffset{}
+	var iter norm.Iter
+	iter.InitString(norm.NFC, text)
+	for !iter.Done() {
+		start := iter.Pos()
+		segment := iter.Next()
+		normalized.Write(segment)
+		for range string(segment) {
+			offsets = append(offsets, offset)
+		}
+		for _, ch := range text[start:iter.Pos()] {
+			offset.utf16Offset += utf16Len(ch)
+		}
+		offset.byteOffset = iter.Pos()
+	}
+	return normalized.String(), append(offsets, offset)
+}
+
+// utf16Len returns the number of UTF-16 code units needed to encode the rune
+func utf16Len(ch rune) int {
+	if ch >= 0x10000 && ch <= unicode.MaxRune {
+		return 2 //nolint:mnd
+	}
+	return 1
+}
+
+// reverseString reverses the order of characters in the string
+func reverseString(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}